@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -12,16 +13,32 @@ import (
 	"time"
 	"unicode"
 
-	"github.com/mark3labs/mcp-go/server"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/dap"
 	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/metrics"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/plugin"
 	"github.com/PrajnaAvidya/godot-peek-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 const (
 	serverName    = "godot-peek-mcp"
 	serverVersion = "0.1.0"
+
+	defaultTransport = "stdio"
+	defaultHTTPAddr  = ":8787"
 )
 
+// argValue scans args for "--flag value" and returns value, or "" if absent.
+func argValue(args []string, flag string) string {
+	for i, a := range args {
+		if a == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
 // sanitizeProjectName matches the C++ plugin's sanitization logic:
 // lowercase, replace non-alphanumeric with dash, trim trailing dashes.
 func sanitizeProjectName(name string) string {
@@ -45,15 +62,60 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	if err := run(ctx); err != nil {
+	// "dap" subcommand runs the Debug Adapter Protocol server instead of the
+	// MCP server, so DAP frontends (VSCode, nvim-dap) can drive Godot directly.
+	if len(os.Args) > 1 && os.Args[1] == "dap" {
+		if err := runDAP(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("fatal: %v", err)
+		}
+		return
+	}
+
+	if err := run(ctx, os.Args[1:]); err != nil {
 		log.Fatalf("fatal: %v", err)
 	}
 }
 
-func run(ctx context.Context) error {
-	// socket path resolution:
-	// 1. GODOT_PEEK_SOCKET env var (explicit full path override)
-	// 2. derive from cwd directory name (matches C++ plugin logic)
+// pluginsDirFromArgs extracts "--plugins-dir path", falling back to
+// GODOT_PEEK_PLUGINS. Returns "" if plugins aren't configured.
+func pluginsDirFromArgs(args []string) string {
+	if dir := argValue(args, "--plugins-dir"); dir != "" {
+		return dir
+	}
+	return os.Getenv("GODOT_PEEK_PLUGINS")
+}
+
+// metricsAddrFromArgs extracts "--metrics-addr host:port", falling back to
+// GODOT_PEEK_METRICS_ADDR when the flag isn't passed. Returns "" if metrics
+// aren't configured, in which case run() skips starting the endpoint.
+func metricsAddrFromArgs(args []string) string {
+	if addr := argValue(args, "--metrics-addr"); addr != "" {
+		return addr
+	}
+	return os.Getenv("GODOT_PEEK_METRICS_ADDR")
+}
+
+// runDAP connects to Godot and serves the Debug Adapter Protocol, over
+// stdio by default or over TCP when passed "--listen host:port".
+func runDAP(ctx context.Context, args []string) error {
+	listen := argValue(args, "--listen")
+
+	socketPath := resolveSocketPath()
+	client := connectSupervised(ctx, socketPath)
+	defer client.Close()
+
+	server := dap.NewServer(client)
+	log.Printf("starting DAP server (connected to %s)", socketPath)
+
+	if listen != "" {
+		return server.ServeTCP(ctx, listen)
+	}
+	return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+}
+
+// resolveSocketPath applies the GODOT_PEEK_SOCKET override, falling back to
+// a name derived from cwd (matches the C++ plugin's sanitization logic).
+func resolveSocketPath() string {
 	socketPath := os.Getenv("GODOT_PEEK_SOCKET")
 	if socketPath == "" {
 		dir, err := os.Getwd()
@@ -67,15 +129,141 @@ func run(ctx context.Context) error {
 	if socketPath == "" {
 		socketPath = godot.DefaultSocketPath
 	}
+	return socketPath
+}
 
-	client := godot.NewClient(socketPath)
+// projectSocketDir is where the C++ plugin's socket-path sanitization
+// writes every project's socket, so it's also where ProjectRegistry looks
+// for other running editors to add as additional projects.
+const projectSocketDir = "/tmp"
+
+// defaultProjectName derives a registry key for the server's default
+// project from its socket path, matching the "<name>" DiscoverSockets
+// extracts from "godot-peek-<name>.sock" so the two can never collide.
+func defaultProjectName(socketPath string) string {
+	name := strings.TrimSuffix(filepath.Base(socketPath), ".sock")
+	name = strings.TrimPrefix(name, "godot-peek-")
+	if name == "" || name == "godot-peek" {
+		return "default"
+	}
+	return name
+}
+
+// projectFlagsFromArgs collects every repeated "--project name=/path/to/socket"
+// flag, wiring up additional Godot projects explicitly (e.g. a socket outside
+// projectSocketDir that scanning wouldn't find).
+func projectFlagsFromArgs(args []string) map[string]string {
+	projects := make(map[string]string)
+	for i, a := range args {
+		if a != "--project" || i+1 >= len(args) {
+			continue
+		}
+		name, path, ok := strings.Cut(args[i+1], "=")
+		if !ok || name == "" || path == "" {
+			continue
+		}
+		projects[name] = path
+	}
+	return projects
+}
+
+// buildProjectRegistry wraps the already-connected default client as the
+// server's default project, then adds every other Godot instance it can
+// find: sockets left by other running editors under projectSocketDir, plus
+// any explicit --project flags. Each additional client gets its own
+// connectSupervised call, so it reconnects independently of the default
+// project.
+func buildProjectRegistry(ctx context.Context, socketPath string, defaultClient *godot.Client, args []string) *godot.ProjectRegistry {
+	registry := godot.NewProjectRegistry(defaultProjectName(socketPath), defaultClient)
+
+	if discovered, err := registry.DiscoverSockets(projectSocketDir); err != nil {
+		log.Printf("project discovery: failed to scan %s for other Godot sockets: %v", projectSocketDir, err)
+	} else {
+		for name, path := range discovered {
+			registry.Add(name, connectSupervised(ctx, path))
+		}
+	}
+
+	for name, path := range projectFlagsFromArgs(args) {
+		registry.Add(name, connectSupervised(ctx, path))
+	}
 
-	// try to connect with retries
-	if err := connectWithRetry(ctx, client, 3); err != nil {
-		return fmt.Errorf("failed to connect to Godot: %w", err)
+	return registry
+}
+
+// loadReplayEvents reads a --record capture from disk for --replay.
+func loadReplayEvents(path string) ([]godot.RecordedEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return godot.LoadReplayLog(f)
+}
+
+// newFileRecorder opens (creating and appending to) the --record capture
+// file and wraps it as a godot.Recorder.
+func newFileRecorder(path string) (*godot.Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return godot.NewRecorder(f), nil
+}
+
+func run(ctx context.Context, args []string) error {
+	// socket path resolution:
+	// 1. GODOT_PEEK_SOCKET env var (explicit full path override)
+	// 2. derive from cwd directory name (matches C++ plugin logic)
+	socketPath := resolveSocketPath()
+
+	var client *godot.Client
+	var registry *godot.ProjectRegistry
+
+	if replayFile := argValue(args, "--replay"); replayFile != "" {
+		// --replay serves a canned --record capture instead of connecting to
+		// Godot, so contributors without an editor checkout (or a bug report
+		// reproducer) can still exercise tools.Register deterministically.
+		// Project discovery doesn't apply here: the replay is a single
+		// recorded session, not a live workspace.
+		events, err := loadReplayEvents(replayFile)
+		if err != nil {
+			return fmt.Errorf("--replay %s: %w", replayFile, err)
+		}
+		client = godot.NewReplayClient(socketPath, events)
+		registry = godot.NewProjectRegistry(defaultProjectName(socketPath), client)
+		log.Printf("replay mode: serving %d recorded event(s) from %s (no Godot editor connection)", len(events), replayFile)
+	} else {
+		client = connectSupervised(ctx, socketPath)
+		registry = buildProjectRegistry(ctx, socketPath, client, args)
 	}
 	defer client.Close()
 
+	// --record <file> captures every request/response frame the default
+	// project's client exchanges with Godot as newline-delimited JSON, for
+	// later --replay or as a tools.Register test fixture.
+	if recordFile := argValue(args, "--record"); recordFile != "" {
+		recorder, err := newFileRecorder(recordFile)
+		if err != nil {
+			log.Printf("--record %s: %v (continuing without capture)", recordFile, err)
+		} else {
+			client.SetRecorder(recorder)
+			log.Printf("recording socket traffic to %s", recordFile)
+		}
+	}
+
+	// --metrics-addr (or GODOT_PEEK_METRICS_ADDR) starts a /metrics endpoint
+	// polling get_monitors in the background; it's optional so most users
+	// pay no cost for it.
+	if metricsAddr := metricsAddrFromArgs(args); metricsAddr != "" {
+		go func() {
+			if err := metrics.Serve(ctx, client, metricsAddr, metrics.DefaultPollInterval); err != nil {
+				log.Printf("metrics server stopped: %v", err)
+			}
+		}()
+		log.Printf("metrics endpoint listening on %s/metrics", metricsAddr)
+	}
+
 	// create MCP server
 	mcpServer := server.NewMCPServer(
 		serverName,
@@ -84,34 +272,101 @@ func run(ctx context.Context) error {
 	)
 
 	// register tools
-	tools.Register(mcpServer, client)
+	tools.Register(mcpServer, registry)
+
+	// --plugins-dir (or GODOT_PEEK_PLUGINS) discovers third-party tool
+	// plugins: executables that speak the internal/plugin RPC protocol and
+	// get a restricted godot.Client handle rather than reimplementing the
+	// socket protocol themselves.
+	if pluginsDir := pluginsDirFromArgs(args); pluginsDir != "" {
+		mgr := plugin.NewManager(plugin.NewGodotHandle(client))
+		if err := mgr.Discover(ctx, pluginsDir); err != nil {
+			log.Printf("plugin discovery failed: %v", err)
+		} else {
+			tools.RegisterPluginTools(mcpServer, mgr)
+			log.Printf("loaded %d plugin tool(s) from %s", len(mgr.Tools()), pluginsDir)
+		}
+	}
+
+	transport := argValue(args, "--transport")
+	if transport == "" {
+		transport = os.Getenv("GODOT_PEEK_TRANSPORT")
+	}
+	if transport == "" {
+		transport = defaultTransport
+	}
 
-	log.Printf("starting MCP server (connected to %s)", socketPath)
+	log.Printf("starting MCP server (connected to %s) via %s transport", socketPath, transport)
 
-	// run stdio transport
-	return server.ServeStdio(mcpServer)
+	switch transport {
+	case "stdio":
+		return server.ServeStdio(mcpServer)
+	case "sse":
+		return serveHTTP(ctx, argValue(args, "--listen"), server.NewSSEServer(mcpServer))
+	case "streamable-http":
+		return serveHTTP(ctx, argValue(args, "--listen"), server.NewStreamableHTTPServer(mcpServer))
+	default:
+		return fmt.Errorf("unknown --transport %q (want stdio, sse, or streamable-http)", transport)
+	}
 }
 
-func connectWithRetry(ctx context.Context, client *godot.Client, maxRetries int) error {
-	var lastErr error
+// serveHTTP runs handler (the SSE or streamable-http mcp-go server) behind
+// a bearer-token auth check, so multiple editors/IDEs - or a remote agent -
+// can share one Godot connection instead of each spawning a child process.
+func serveHTTP(ctx context.Context, addr string, handler http.Handler) error {
+	if addr == "" {
+		addr = defaultHTTPAddr
+	}
 
-	for i := 0; i < maxRetries; i++ {
-		if i > 0 {
-			log.Printf("retrying connection (%d/%d)...", i+1, maxRetries)
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(time.Duration(i) * time.Second):
-			}
-		}
+	srv := &http.Server{Addr: addr, Handler: authMiddleware(handler)}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
 
-		err := client.Connect(ctx)
-		if err == nil {
-			return nil
-		}
-		lastErr = err
-		log.Printf("connection attempt failed: %v", err)
+	log.Printf("HTTP transport listening on %s", addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("http transport: %w", err)
 	}
+	return nil
+}
+
+// authMiddleware requires "Authorization: Bearer <token>" when
+// GODOT_PEEK_AUTH_TOKEN is set. With no token configured, auth is left to
+// the operator (e.g. a reverse proxy or loopback-only bind).
+func authMiddleware(next http.Handler) http.Handler {
+	token := os.Getenv("GODOT_PEEK_AUTH_TOKEN")
+	if token == "" {
+		return next
+	}
+
+	want := "Bearer " + token
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != want {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// initialConnectWait bounds how long connectSupervised waits for the first
+// connect attempt before starting the server anyway; Supervise keeps
+// retrying in the background either way, so a slow-starting editor no
+// longer means a failed launch.
+const initialConnectWait = 10 * time.Second
 
-	return lastErr
+// connectSupervised creates a Client and starts its Supervise goroutine,
+// which reconnects with backoff for the life of ctx. It waits briefly for
+// the first connect so startup logs reflect reality, but doesn't fail if
+// the editor isn't up yet - tool calls surface that via waitForConnection
+// and godot_status instead of the server refusing to start.
+func connectSupervised(ctx context.Context, socketPath string) *godot.Client {
+	client := godot.NewClient(socketPath)
+	go client.Supervise(ctx)
+
+	if err := client.WaitUntilConnected(ctx, initialConnectWait); err != nil {
+		log.Printf("godot editor not reachable yet at %s, will keep retrying in the background: %v", socketPath, err)
+	}
+	return client
 }
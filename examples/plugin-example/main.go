@@ -0,0 +1,171 @@
+// Command plugin-example is a reference implementation of the
+// godot-peek-mcp plugin protocol: a standalone executable that speaks
+// line-delimited JSON-RPC over its own stdin/stdout. Point --plugins-dir
+// (or GODOT_PEEK_PLUGINS) at a directory containing this binary and the
+// MCP server will launch it, fetch its manifest, and proxy calls to it.
+//
+// It declares one tool, "example_echo", and demonstrates calling back into
+// Godot via the restricted "godot.get_node_tree" RPC the host exposes.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// envelope mirrors internal/plugin's frame shape: a request has Method set,
+// a response doesn't. Plugins and the host both read/write this same shape.
+type envelope struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type toolManifest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+var (
+	stdout     = bufio.NewWriter(os.Stdout)
+	writeMu    sync.Mutex
+	nextID     atomic.Int64
+	pending    = make(map[int64]chan envelope)
+	pendingMu  sync.Mutex
+	echoSchema = json.RawMessage(`{
+		"type": "object",
+		"properties": {"message": {"type": "string"}},
+		"required": ["message"]
+	}`)
+)
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg envelope
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+
+		if msg.Method == "" {
+			// response to a request we issued (a "godot.*" callback)
+			pendingMu.Lock()
+			ch, ok := pending[msg.ID]
+			if ok {
+				delete(pending, msg.ID)
+			}
+			pendingMu.Unlock()
+			if ok {
+				ch <- msg
+			}
+			continue
+		}
+
+		go handleRequest(msg)
+	}
+}
+
+func handleRequest(req envelope) {
+	resp := envelope{ID: req.ID}
+
+	switch req.Method {
+	case "manifest":
+		manifest := struct {
+			Tools []toolManifest `json:"tools"`
+		}{
+			Tools: []toolManifest{
+				{Name: "example_echo", Description: "Echo a message back, followed by the size in bytes of the current Godot scene tree", Schema: echoSchema},
+			},
+		}
+		resp.Result, _ = json.Marshal(manifest)
+
+	case "call":
+		var params struct {
+			Name string          `json:"name"`
+			Args json.RawMessage `json:"args"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		result, err := handleCall(params.Name, params.Args)
+		if err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		resp.Result = result
+
+	default:
+		resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+	}
+
+	writeFrame(resp)
+}
+
+func handleCall(name string, rawArgs json.RawMessage) (json.RawMessage, error) {
+	if name != "example_echo" {
+		return nil, fmt.Errorf("unknown tool %q", name)
+	}
+
+	var args struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return nil, err
+	}
+
+	tree, err := callGodot("godot.get_node_tree", nil)
+	if err != nil {
+		return nil, fmt.Errorf("godot.get_node_tree: %w", err)
+	}
+	var treeText string
+	json.Unmarshal(tree, &treeText)
+
+	return json.Marshal(fmt.Sprintf("%s (scene tree: %d bytes)", args.Message, len(treeText)))
+}
+
+// callGodot issues a restricted callback to the host and blocks for its
+// response, same pending-map pattern the host uses for plugin calls.
+func callGodot(method string, params json.RawMessage) (json.RawMessage, error) {
+	id := nextID.Add(1)
+	ch := make(chan envelope, 1)
+
+	pendingMu.Lock()
+	pending[id] = ch
+	pendingMu.Unlock()
+
+	writeFrame(envelope{ID: id, Method: method, Params: params})
+
+	resp := <-ch
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	return resp.Result, nil
+}
+
+func writeFrame(msg envelope) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	stdout.Write(data)
+	stdout.WriteByte('\n')
+	stdout.Flush()
+}
@@ -0,0 +1,51 @@
+// Package bridge holds request-to-client translation helpers shared between
+// the MCP tool handlers (internal/tools) and the Debug Adapter Protocol
+// server (internal/dap), so the two front ends don't each reimplement how a
+// step mode or a locals/properties listing maps onto godot.Client calls.
+package bridge
+
+import (
+	"fmt"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+)
+
+// StepModes are the values godot.Client.DebugStep accepts.
+var StepModes = []string{"into", "over", "out"}
+
+// NormalizeStepMode validates a requested step mode, defaulting empty to "over".
+func NormalizeStepMode(mode string) (string, error) {
+	if mode == "" {
+		mode = "over"
+	}
+	for _, m := range StepModes {
+		if mode == m {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("mode must be 'into', 'over', or 'out'")
+}
+
+// FormatNameValueLines renders a list of name/value pairs (locals or node
+// properties share this shape) as "name = value" lines, the format both the
+// get_debugger_locals/get_remote_node_properties MCP tools print.
+func FormatNameValueLines(items []godot.LocalVariable) string {
+	var out string
+	for _, item := range items {
+		out += fmt.Sprintf("%s = %s\n", item.Name, item.Value)
+	}
+	return out
+}
+
+// StepModeDescription is the human-readable summary of a completed step,
+// used for the MCP tool result text.
+func StepModeDescription(mode string) string {
+	switch mode {
+	case "into":
+		return "Stepped into function"
+	case "out":
+		return "Stepped out of function"
+	default:
+		return "Stepped to next line"
+	}
+}
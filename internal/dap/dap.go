@@ -0,0 +1,509 @@
+// Package dap exposes the Godot debugger through a standard Debug Adapter
+// Protocol server, so DAP-capable frontends (VSCode, nvim-dap, etc.) can
+// drive Godot without going through the MCP tool layer. It wraps the same
+// godot.Client used by internal/tools, translating requests with the shared
+// helpers in internal/bridge so the two front ends don't diverge.
+package dap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/bridge"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/stack"
+)
+
+// message is the envelope shared by requests, responses, and events.
+type message struct {
+	Seq        int             `json:"seq"`
+	Type       string          `json:"type"`
+	Command    string          `json:"command,omitempty"`
+	Event      string          `json:"event,omitempty"`
+	RequestSeq int             `json:"request_seq,omitempty"`
+	Success    bool            `json:"success,omitempty"`
+	Message    string          `json:"message,omitempty"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	Body       interface{}     `json:"body,omitempty"`
+}
+
+// Server adapts a single DAP client connection onto a godot.Client.
+type Server struct {
+	client *godot.Client
+
+	seqMu sync.Mutex
+	seq   int
+
+	outMu sync.Mutex
+	out   io.Writer
+
+	bpMu        sync.Mutex
+	breakpoints map[string][]int // source path -> line numbers, for setBreakpoints diffing
+
+	varMu   sync.Mutex
+	nextRef int
+	frames  map[int]int // variablesReference -> stack frame index (scopes request)
+
+	pollCancel context.CancelFunc
+}
+
+// NewServer creates a DAP server wrapping an already-connected godot.Client.
+func NewServer(client *godot.Client) *Server {
+	return &Server{
+		client:      client,
+		breakpoints: make(map[string][]int),
+		frames:      make(map[int]int),
+		nextRef:     1,
+	}
+}
+
+// ServeStdio runs the DAP server over stdin/stdout.
+func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writer) error {
+	return s.serve(ctx, stdin, stdout)
+}
+
+// ServeTCP runs the DAP server accepting a single connection on addr.
+func (s *Server) ServeTCP(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	defer ln.Close()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return fmt.Errorf("accept: %w", err)
+	}
+	defer conn.Close()
+
+	return s.serve(ctx, conn, conn)
+}
+
+func (s *Server) serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	reader := bufio.NewReader(in)
+
+	for {
+		msg, err := readMessage(reader)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read dap message: %w", err)
+		}
+		if msg.Type != "request" {
+			continue
+		}
+		s.handleRequest(ctx, msg)
+	}
+}
+
+// readMessage parses one Content-Length-framed DAP message.
+func readMessage(r *bufio.Reader) (*message, error) {
+	var contentLength int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "Content-Length:") {
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Content-Length:")))
+			if err != nil {
+				return nil, fmt.Errorf("bad Content-Length: %w", err)
+			}
+			contentLength = n
+		}
+	}
+	if contentLength == 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("unmarshal dap message: %w", err)
+	}
+	return &msg, nil
+}
+
+func (s *Server) write(msg message) {
+	s.seqMu.Lock()
+	s.seq++
+	msg.Seq = s.seq
+	s.seqMu.Unlock()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("[dap] marshal: %v", err)
+		return
+	}
+
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(data))
+	s.out.Write(data)
+}
+
+func (s *Server) sendEvent(event string, body interface{}) {
+	s.write(message{Type: "event", Event: event, Body: body})
+}
+
+func (s *Server) sendResponse(req *message, success bool, errMsg string, body interface{}) {
+	s.write(message{
+		Type:       "response",
+		RequestSeq: req.Seq,
+		Command:    req.Command,
+		Success:    success,
+		Message:    errMsg,
+		Body:       body,
+	})
+}
+
+func (s *Server) handleRequest(ctx context.Context, req *message) {
+	switch req.Command {
+	case "initialize":
+		s.handleInitialize(req)
+	case "launch", "attach":
+		s.handleLaunch(ctx, req)
+	case "setBreakpoints":
+		s.handleSetBreakpoints(ctx, req)
+	case "continue":
+		s.handleSimple(ctx, req, func() error { _, err := s.client.DebugContinue(ctx); return err })
+	case "next":
+		s.handleStep(ctx, req, "over")
+	case "stepIn":
+		s.handleStep(ctx, req, "into")
+	case "stepOut":
+		s.handleStep(ctx, req, "out")
+	case "pause":
+		s.handleSimple(ctx, req, func() error { _, err := s.client.DebugBreak(ctx); return err })
+	case "stackTrace":
+		s.handleStackTrace(ctx, req)
+	case "scopes":
+		s.handleScopes(req)
+	case "variables":
+		s.handleVariables(ctx, req)
+	case "evaluate":
+		s.handleEvaluate(ctx, req)
+	case "disconnect", "terminate":
+		s.handleTerminate(ctx, req)
+	case "threads":
+		s.handleThreads(req)
+	default:
+		s.sendResponse(req, false, fmt.Sprintf("unsupported command: %s", req.Command), nil)
+	}
+}
+
+func (s *Server) handleInitialize(req *message) {
+	s.sendResponse(req, true, "", map[string]interface{}{
+		"supportsConditionalBreakpoints":    true,
+		"supportsHitConditionalBreakpoints": true,
+		"supportsLogPoints":                 true,
+		"supportsEvaluateForHovers":         true,
+	})
+	s.sendEvent("initialized", nil)
+}
+
+type launchArgs struct {
+	ScenePath      string          `json:"scenePath"`
+	Overrides      godot.Overrides `json:"overrides"`
+	TimeoutSeconds float64         `json:"timeout_seconds"`
+}
+
+func (s *Server) handleLaunch(ctx context.Context, req *message) {
+	var args launchArgs
+	json.Unmarshal(req.Arguments, &args)
+
+	var (
+		result *godot.GenericResult
+		err    error
+	)
+	switch {
+	case args.ScenePath != "":
+		result, err = s.client.RunScene(ctx, args.ScenePath, args.Overrides, args.TimeoutSeconds)
+	default:
+		result, err = s.client.RunMainScene(ctx, args.Overrides, args.TimeoutSeconds)
+	}
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	s.sendResponse(req, true, "", nil)
+
+	if result.ErrorDetected {
+		s.sendEvent("stopped", map[string]interface{}{"reason": "exception", "threadId": 1})
+	}
+
+	s.startOutputPump(ctx)
+	s.startStatePump(ctx)
+}
+
+// startOutputPump streams get_output to DAP "output" events on a goroutine.
+func (s *Server) startOutputPump(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				out, err := s.client.GetOutputFromGodot(ctx, false, true)
+				if err != nil || out.Length == 0 {
+					continue
+				}
+				category := "stdout"
+				if strings.Contains(strings.ToLower(out.Output), "error") {
+					category = "stderr"
+				}
+				s.sendEvent("output", map[string]interface{}{"category": category, "output": out.Output})
+			}
+		}
+	}()
+}
+
+// startStatePump polls GetDebuggerState and emits "stopped" when a pause
+// begins and "terminated" once the debugger session is no longer active.
+func (s *Server) startStatePump(ctx context.Context) {
+	pumpCtx, cancel := context.WithCancel(ctx)
+	s.pollCancel = cancel
+
+	go func() {
+		wasPaused := false
+		ticker := time.NewTicker(300 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-pumpCtx.Done():
+				return
+			case <-ticker.C:
+				state, err := s.client.GetDebuggerState(ctx)
+				if err != nil {
+					continue
+				}
+				if state.Paused && !wasPaused {
+					stop, err := s.processPause(ctx)
+					if err != nil {
+						log.Printf("[dap] process breakpoint pause: %v", err)
+					}
+					if stop {
+						s.sendEvent("stopped", map[string]interface{}{"reason": "breakpoint", "threadId": 1})
+					}
+				}
+				wasPaused = state.Paused
+				if !state.Active {
+					s.sendEvent("terminated", nil)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// processPause resolves the top stack frame's path:line for a freshly
+// observed pause and runs it through ProcessBreakpointPause, so a tracked
+// condition/hit-condition/logpoint can transparently resume execution
+// instead of the pump always surfacing a "stopped" event. A pause with no
+// resolvable frame (e.g. not paused on a GDScript line) always stops.
+func (s *Server) processPause(ctx context.Context) (stop bool, err error) {
+	trace, err := s.client.GetStackTrace(ctx, godot.GetStackTraceParams{})
+	if err != nil {
+		return true, err
+	}
+	frames := stack.ParseFrames(trace.StackTrace)
+	if len(frames) == 0 {
+		return true, nil
+	}
+	return s.client.ProcessBreakpointPause(ctx, frames[0].Source, frames[0].Line)
+}
+
+func (s *Server) handleSetBreakpoints(ctx context.Context, req *message) {
+	var args struct {
+		Source struct {
+			Path string `json:"path"`
+		} `json:"source"`
+		Breakpoints []struct {
+			Line         int    `json:"line"`
+			Condition    string `json:"condition"`
+			HitCondition string `json:"hitCondition"`
+			LogMessage   string `json:"logMessage"`
+		} `json:"breakpoints"`
+	}
+	json.Unmarshal(req.Arguments, &args)
+
+	s.bpMu.Lock()
+	prev := s.breakpoints[args.Source.Path]
+	next := make([]int, 0, len(args.Breakpoints))
+	for _, bp := range args.Breakpoints {
+		next = append(next, bp.Line)
+	}
+	s.breakpoints[args.Source.Path] = next
+	s.bpMu.Unlock()
+
+	// diff: clear lines no longer present, set new/kept lines
+	keep := make(map[int]bool, len(next))
+	for _, line := range next {
+		keep[line] = true
+	}
+	for _, line := range prev {
+		if !keep[line] {
+			s.client.SetBreakpoint(ctx, args.Source.Path, line, false, "", "", "")
+		}
+	}
+
+	verified := make([]map[string]interface{}, 0, len(args.Breakpoints))
+	for _, bp := range args.Breakpoints {
+		_, err := s.client.SetBreakpoint(ctx, args.Source.Path, bp.Line, true, bp.Condition, bp.HitCondition, bp.LogMessage)
+		verified = append(verified, map[string]interface{}{"verified": err == nil, "line": bp.Line})
+	}
+
+	s.sendResponse(req, true, "", map[string]interface{}{"breakpoints": verified})
+}
+
+func (s *Server) handleSimple(ctx context.Context, req *message, fn func() error) {
+	if err := fn(); err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	s.sendResponse(req, true, "", nil)
+}
+
+func (s *Server) handleStep(ctx context.Context, req *message, mode string) {
+	mode, err := bridge.NormalizeStepMode(mode)
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	if _, err := s.client.DebugStep(ctx, mode); err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	s.sendResponse(req, true, "", nil)
+}
+
+func (s *Server) handleStackTrace(ctx context.Context, req *message) {
+	trace, err := s.client.GetStackTrace(ctx, godot.GetStackTraceParams{})
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	// one synthetic top frame per line of the raw trace; good enough for a
+	// frontend to show something clickable without a full frame parser
+	lines := strings.Split(strings.TrimSpace(trace.StackTrace), "\n")
+	frames := make([]map[string]interface{}, 0, len(lines))
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		frames = append(frames, map[string]interface{}{
+			"id":     i,
+			"name":   line,
+			"line":   0,
+			"column": 0,
+		})
+	}
+	s.sendResponse(req, true, "", map[string]interface{}{"stackFrames": frames, "totalFrames": len(frames)})
+}
+
+// handleThreads reports the single thread every "stopped" event's
+// threadId:1 refers to. Frontends issue a threads request in response to
+// every "stopped" event before they'll ask for stackTrace, so without this
+// they stall right after the first breakpoint hit.
+func (s *Server) handleThreads(req *message) {
+	s.sendResponse(req, true, "", map[string]interface{}{
+		"threads": []map[string]interface{}{
+			{"id": 1, "name": "main"},
+		},
+	})
+}
+
+func (s *Server) handleScopes(req *message) {
+	var args struct {
+		FrameID int `json:"frameId"`
+	}
+	json.Unmarshal(req.Arguments, &args)
+
+	ref := s.allocRef(args.FrameID)
+	s.sendResponse(req, true, "", map[string]interface{}{
+		"scopes": []map[string]interface{}{
+			{"name": "Locals", "variablesReference": ref, "expensive": false},
+		},
+	})
+}
+
+func (s *Server) allocRef(frameIndex int) int {
+	s.varMu.Lock()
+	defer s.varMu.Unlock()
+	ref := s.nextRef
+	s.nextRef++
+	s.frames[ref] = frameIndex
+	return ref
+}
+
+func (s *Server) handleVariables(ctx context.Context, req *message) {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+	json.Unmarshal(req.Arguments, &args)
+
+	s.varMu.Lock()
+	frameIndex := s.frames[args.VariablesReference]
+	s.varMu.Unlock()
+
+	locals, err := s.client.GetLocals(ctx, frameIndex)
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+
+	vars := make([]map[string]interface{}, 0, len(locals.Locals))
+	for _, l := range locals.Locals {
+		vars = append(vars, map[string]interface{}{"name": l.Name, "value": l.Value, "type": l.Type, "variablesReference": 0})
+	}
+	s.sendResponse(req, true, "", map[string]interface{}{"variables": vars})
+}
+
+func (s *Server) handleEvaluate(ctx context.Context, req *message) {
+	var args struct {
+		Expression string `json:"expression"`
+	}
+	json.Unmarshal(req.Arguments, &args)
+
+	result, err := s.client.EvaluateExpression(ctx, args.Expression)
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+		return
+	}
+	s.sendResponse(req, true, "", map[string]interface{}{"result": result.Value, "type": result.Type, "variablesReference": 0})
+}
+
+func (s *Server) handleTerminate(ctx context.Context, req *message) {
+	if s.pollCancel != nil {
+		s.pollCancel()
+	}
+	err := s.client.StopScene(ctx)
+	if err != nil {
+		s.sendResponse(req, false, err.Error(), nil)
+	} else {
+		s.sendResponse(req, true, "", nil)
+	}
+	s.sendEvent("exited", map[string]interface{}{"exitCode": 0})
+	s.sendEvent("terminated", nil)
+}
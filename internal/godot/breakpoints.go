@@ -0,0 +1,148 @@
+package godot
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BreakpointInfo describes a conditional/logpoint breakpoint tracked
+// client-side, since Godot's editor debugger has no native support for
+// conditions, hit counts, or logpoints.
+type BreakpointInfo struct {
+	Path         string `json:"path"`
+	Line         int    `json:"line"`
+	Condition    string `json:"condition,omitempty"`
+	HitCondition string `json:"hit_condition,omitempty"`
+	LogMessage   string `json:"log_message,omitempty"`
+	HitCount     int    `json:"hit_count"`
+}
+
+func breakpointKey(path string, line int) string {
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
+// BreakpointRegistry tracks conditional/hit-count/logpoint metadata for
+// breakpoints, keyed by "path:line". Godot's C++ plugin only knows about
+// plain enabled/disabled breakpoints; everything in this registry is
+// evaluated here when a pause is observed.
+type BreakpointRegistry struct {
+	mu      sync.Mutex
+	entries map[string]*BreakpointInfo
+}
+
+// NewBreakpointRegistry creates an empty registry.
+func NewBreakpointRegistry() *BreakpointRegistry {
+	return &BreakpointRegistry{entries: make(map[string]*BreakpointInfo)}
+}
+
+// Set records (or clears, if condition/hitCondition/logMessage are all
+// empty) the metadata for a breakpoint at path:line.
+func (r *BreakpointRegistry) Set(path string, line int, condition, hitCondition, logMessage string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if condition == "" && hitCondition == "" && logMessage == "" {
+		delete(r.entries, breakpointKey(path, line))
+		return
+	}
+
+	r.entries[breakpointKey(path, line)] = &BreakpointInfo{
+		Path:         path,
+		Line:         line,
+		Condition:    condition,
+		HitCondition: hitCondition,
+		LogMessage:   logMessage,
+	}
+}
+
+// Remove deletes any tracked metadata for path:line (called alongside a
+// plain SetBreakpoint(..., enabled=false)).
+func (r *BreakpointRegistry) Remove(path string, line int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, breakpointKey(path, line))
+}
+
+// Clear removes all tracked breakpoints, mirroring clear_breakpoints.
+func (r *BreakpointRegistry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]*BreakpointInfo)
+}
+
+// Get returns a copy of the tracked entry for path:line, if any.
+func (r *BreakpointRegistry) Get(path string, line int) (BreakpointInfo, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[breakpointKey(path, line)]
+	if !ok {
+		return BreakpointInfo{}, false
+	}
+	return *entry, true
+}
+
+// RecordHit increments the hit counter for path:line and returns the new
+// count. Breakpoints with no tracked metadata simply aren't counted.
+func (r *BreakpointRegistry) RecordHit(path string, line int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[breakpointKey(path, line)]
+	if !ok {
+		return 0
+	}
+	entry.HitCount++
+	return entry.HitCount
+}
+
+// List returns all tracked breakpoints, for surfacing through get_debugger_state.
+func (r *BreakpointRegistry) List() []BreakpointInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]BreakpointInfo, 0, len(r.entries))
+	for _, entry := range r.entries {
+		out = append(out, *entry)
+	}
+	return out
+}
+
+// hitConditionRe matches a hit-condition expression: an optional comparison
+// operator (>=, <=, >, <, =, %) followed by an integer, e.g. ">=5", "%10", "=3".
+// A bare integer is treated as "=N".
+var hitConditionRe = regexp.MustCompile(`^\s*(>=|<=|>|<|=|%)?\s*(\d+)\s*$`)
+
+// evalHitCondition reports whether the given hit count satisfies a
+// hit-condition expression.
+func evalHitCondition(expr string, hits int) (bool, error) {
+	m := hitConditionRe.FindStringSubmatch(strings.TrimSpace(expr))
+	if m == nil {
+		return false, fmt.Errorf("invalid hit_condition %q", expr)
+	}
+	op := m[1]
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return false, fmt.Errorf("invalid hit_condition %q: %w", expr, err)
+	}
+
+	switch op {
+	case "", "=":
+		return hits == n, nil
+	case ">=":
+		return hits >= n, nil
+	case "<=":
+		return hits <= n, nil
+	case ">":
+		return hits > n, nil
+	case "<":
+		return hits < n, nil
+	case "%":
+		return n != 0 && hits%n == 0, nil
+	default:
+		return false, fmt.Errorf("unsupported hit_condition operator %q", op)
+	}
+}
+
+// logMessagePlaceholderRe matches "{expr}" interpolations in a logpoint message.
+var logMessagePlaceholderRe = regexp.MustCompile(`\{([^{}]+)\}`)
@@ -2,14 +2,21 @@ package godot
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"net"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/stack"
 )
 
 const (
@@ -17,25 +24,121 @@ const (
 	OverridesPath       = "/tmp/godot_peek_overrides.json"
 	MaxReconnectBackoff = 30 * time.Second
 	MaxOutputBuffer     = 1000
+
+	initialReconnectBackoff = 100 * time.Millisecond
+
+	// defaultHeartbeatInterval is used until negotiateHeartbeat's "hello"
+	// exchange agrees on a different one with the editor plugin.
+	defaultHeartbeatInterval = 10 * time.Second
+
+	// heartbeatTimeout bounds a single ping's sendRequestTimeout call.
+	heartbeatTimeout = 5 * time.Second
+
+	// maxConsecutiveHeartbeatFailures pings the client tolerates before
+	// declaring the connection dead and handing it back to Supervise.
+	maxConsecutiveHeartbeatFailures = 2
 )
 
+// errCanceled is returned by sendRequest when a pending call is aborted via
+// the client's shared deadline (SetDeadline) rather than its own context.
+var errCanceled = errors.New("request canceled: client deadline exceeded")
+
+// errDisconnected marks a pending request's response as failed because the
+// socket dropped mid-call, so callers can tell "Godot said no" apart from
+// "the connection went away while waiting" without a bare timeout.
+var errDisconnected = &ResponseError{Code: -32000, Message: "connection to Godot lost; Supervise is reconnecting"}
+
 // Client manages Unix socket connection to Godot editor plugin
 type Client struct {
 	socketPath string
 
 	mu           sync.RWMutex
 	conn         net.Conn
-	reader       *bufio.Scanner
+	reader       *bufio.Reader
 	connected    bool
 	outputBuffer []OutputNotification
 
+	// recentFrames is the ring buffer StreamScreenshots fills and
+	// RecentFrames reads, guarded by mu like outputBuffer.
+	recentFrames []ScreenshotFrame
+
+	// framed is negotiated once per connection (see negotiateFraming): true
+	// once Godot has confirmed it understands the length-prefixed wire
+	// format, false for a not-yet-upgraded peer still on newline-JSON.
+	// compressThreshold is the body size past which outgoing framed
+	// messages are gzip-compressed; 0 disables compression entirely.
+	framed            bool
+	compressThreshold int
+
 	// pending requests waiting for response
 	pending   map[int64]chan *Response
 	pendingMu sync.Mutex
 
+	// replayable tracks which pending ids were sent via SendIdempotentRequest
+	// (keyed the same as pending, guarded by pendingMu): failPendingRequests
+	// stashes these into replayQueue instead of failing them outright, for
+	// Supervise to reissue once reconnected.
+	replayable map[int64]replayInfo
+
+	replayQueueMu sync.Mutex
+	replayQueue   []replayEntry
+
 	// channel for output notifications
 	outputCh chan OutputNotification
 
+	// subscribers to server-initiated notifications, keyed by method name
+	// (subs) and by subscription id (subsByID, for O(1) Unsubscribe)
+	subsMu    sync.Mutex
+	subs      map[string]map[uint64]*methodSub
+	subsByID  map[uint64]*methodSub
+	nextSubID uint64
+
+	// subscribers to the topic-normalized Event layer (see events.go)
+	eventSubsMu    sync.Mutex
+	eventSubs      map[int]*eventSub
+	nextEventSubID int
+
+	// deadline plumbing: a single shared cancelCh closed when the client-wide
+	// deadline set via SetDeadline expires, modeled on net.Conn.SetDeadline.
+	deadlineMu    sync.Mutex
+	deadlineTimer *time.Timer
+	cancelCh      chan struct{}
+
+	// structured logging: defaults to a no-op logger at LevelInfo so callers
+	// who never touch it see no behavior change
+	logMu     sync.RWMutex
+	logger    Logger
+	logLevel  Level
+	logFilter func(level Level, method string, id int64) bool
+
+	// Breakpoints tracks conditional/hit-count/logpoint metadata that Godot's
+	// editor debugger has no native support for.
+	Breakpoints *BreakpointRegistry
+
+	// supervisor state: lastErr is the most recent connect/keepalive failure,
+	// surfaced via Status() so tool callers get a reason rather than a bare
+	// "disconnected". supervisorStarted guards against starting more than
+	// one Supervise goroutine per Client.
+	statusMu          sync.RWMutex
+	lastErr           error
+	supervisorStarted bool
+
+	// heartbeat state: lastSeen is stamped on connect and on every response
+	// to a sendRequest call (pings and ordinary calls alike; SendBatch
+	// responses don't stamp it), and heartbeatInterval
+	// holds the interval Connect's negotiateHeartbeat agreed on (or the
+	// default, if the editor plugin doesn't understand "hello" params).
+	lastSeenMu        sync.RWMutex
+	lastSeen          time.Time
+	heartbeatMu       sync.RWMutex
+	heartbeatInterval time.Duration
+
+	// recorder captures every request/response frame to a --record log when
+	// set; replay answers sendRequest from a loaded log instead of touching
+	// the socket when the client was built with NewReplayClient.
+	recorder *Recorder
+	replay   *replayState
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -52,16 +155,72 @@ func NewClient(socketPath string) *Client {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := &Client{
-		socketPath: socketPath,
-		pending:    make(map[int64]chan *Response),
-		outputCh:   make(chan OutputNotification, 100),
-		ctx:        ctx,
-		cancel:     cancel,
+		socketPath:        socketPath,
+		pending:           make(map[int64]chan *Response),
+		replayable:        make(map[int64]replayInfo),
+		outputCh:          make(chan OutputNotification, 100),
+		subs:              make(map[string]map[uint64]*methodSub),
+		subsByID:          make(map[uint64]*methodSub),
+		eventSubs:         make(map[int]*eventSub),
+		cancelCh:          make(chan struct{}),
+		logger:            noopLogger{},
+		logLevel:          LevelInfo,
+		Breakpoints:       NewBreakpointRegistry(),
+		compressThreshold: defaultCompressThreshold,
+		heartbeatInterval: defaultHeartbeatInterval,
+		ctx:               ctx,
+		cancel:            cancel,
 	}
 
 	return c
 }
 
+// SetCompressThreshold changes the body size past which framed outgoing
+// messages are gzip-compressed (screenshots, big scene trees); 0 disables
+// compression. Has no effect once framed is negotiated as false.
+func (c *Client) SetCompressThreshold(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.compressThreshold = n
+}
+
+// SetDeadline sets (or clears, with a zero time) a deadline shared by every
+// in-flight and future sendRequest call, mirroring net.Conn.SetDeadline.
+// Stops any existing timer first; if that timer had already fired, cancelCh
+// is replaced so a stale deadline can't cancel calls made after it expired.
+// A deadline already in the past closes cancelCh immediately.
+func (c *Client) SetDeadline(t time.Time) {
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+
+	if c.deadlineTimer != nil && !c.deadlineTimer.Stop() {
+		c.cancelCh = make(chan struct{})
+	}
+
+	if t.IsZero() {
+		c.deadlineTimer = nil
+		return
+	}
+
+	if !t.After(time.Now()) {
+		close(c.cancelCh)
+		c.deadlineTimer = nil
+		return
+	}
+
+	ch := c.cancelCh
+	c.deadlineTimer = time.AfterFunc(t.Sub(time.Now()), func() {
+		close(ch)
+	})
+}
+
+// deadlineCancelCh returns the channel closed when the current deadline expires.
+func (c *Client) deadlineCancelCh() chan struct{} {
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+	return c.cancelCh
+}
+
 // Connect establishes connection to Godot
 func (c *Client) Connect(ctx context.Context) error {
 	conn, err := net.Dial("unix", c.socketPath)
@@ -69,19 +228,145 @@ func (c *Client) Connect(ctx context.Context) error {
 		return fmt.Errorf("dial unix socket: %w", err)
 	}
 
+	reader := bufio.NewReader(conn)
+	framed := c.negotiateFraming(conn, reader)
+
 	c.mu.Lock()
 	c.conn = conn
-	c.reader = bufio.NewScanner(conn)
+	c.reader = reader
+	c.framed = framed
 	c.connected = true
 	c.mu.Unlock()
 
 	// start reading messages
 	go c.readLoop()
 
-	log.Printf("[godot] Connected to %s", c.socketPath)
+	c.touchLastSeen()
+	c.negotiateHeartbeat(ctx)
+
+	log.Printf("[godot] Connected to %s (framed=%v, heartbeat=%s)", c.socketPath, framed, c.HeartbeatInterval())
+	return nil
+}
+
+// negotiateFraming sends a length-prefixed "hello" frame announcing
+// frameProtocolVersion and inspects the first byte Godot replies with: a
+// frameMagic byte means the peer understood it, so framing (and gzip over
+// compressThreshold) is used for the rest of the connection; anything else -
+// old-style newline-delimited JSON, or nothing before the deadline - means
+// the peer hasn't been upgraded yet, and the connection falls back to the
+// original wire format so it can still be upgraded independently.
+func (c *Client) negotiateFraming(conn net.Conn, reader *bufio.Reader) bool {
+	payload, err := json.Marshal(newRequest(nextID(), "hello", map[string]interface{}{"protocol_version": frameProtocolVersion}))
+	if err != nil {
+		return false
+	}
+	if err := writeFrame(conn, payload, c.compressThreshold); err != nil {
+		return false
+	}
+
+	conn.SetReadDeadline(time.Now().Add(helloNegotiationTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	b, err := reader.Peek(1)
+	return err == nil && len(b) == 1 && b[0] == frameMagic
+}
+
+// negotiateHeartbeat asks the editor plugin to agree on a ping interval via
+// a "hello" request - distinct from negotiateFraming's raw framing
+// handshake, which runs before readLoop starts and never reaches the
+// pending-request machinery - falling back to defaultHeartbeatInterval if
+// the call errors, times out, or the reply omits an interval.
+func (c *Client) negotiateHeartbeat(ctx context.Context) {
+	resp, err := c.sendRequestTimeout(ctx, "hello", map[string]interface{}{
+		"heartbeat_interval_ms": int64(defaultHeartbeatInterval / time.Millisecond),
+	}, helloNegotiationTimeout)
+	if err != nil || resp.Error != nil || resp.Result == nil {
+		return
+	}
+
+	var hello struct {
+		HeartbeatIntervalMs int64 `json:"heartbeat_interval_ms"`
+	}
+	if err := json.Unmarshal(*resp.Result, &hello); err != nil || hello.HeartbeatIntervalMs <= 0 {
+		return
+	}
+
+	c.heartbeatMu.Lock()
+	c.heartbeatInterval = time.Duration(hello.HeartbeatIntervalMs) * time.Millisecond
+	c.heartbeatMu.Unlock()
+}
+
+// HeartbeatInterval returns the interval negotiateHeartbeat agreed on (or
+// defaultHeartbeatInterval, if negotiation never succeeded).
+func (c *Client) HeartbeatInterval() time.Duration {
+	c.heartbeatMu.RLock()
+	defer c.heartbeatMu.RUnlock()
+	return c.heartbeatInterval
+}
+
+// touchLastSeen stamps the moment Godot was last known to be responsive -
+// every successful response, not just pings, counts.
+func (c *Client) touchLastSeen() {
+	c.lastSeenMu.Lock()
+	c.lastSeen = time.Now()
+	c.lastSeenMu.Unlock()
+}
+
+// LastSeen returns the last time the editor plugin answered anything, or
+// the zero Time if it never has.
+func (c *Client) LastSeen() time.Time {
+	c.lastSeenMu.RLock()
+	defer c.lastSeenMu.RUnlock()
+	return c.lastSeen
+}
+
+// HealthCheck sends a single "ping" and reports whether the editor answered
+// within heartbeatTimeout, so a tool handler can surface
+// "editor unresponsive" immediately instead of blocking on sendRequest's
+// full 30s timeout.
+func (c *Client) HealthCheck(ctx context.Context) error {
+	if !c.IsConnected() {
+		return fmt.Errorf("not connected to Godot")
+	}
+
+	resp, err := c.sendRequestTimeout(ctx, "ping", nil, heartbeatTimeout)
+	if err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("ping failed: %s", resp.Error.Message)
+	}
+
+	c.touchLastSeen()
+	return nil
+}
+
+// writeMessage sends one JSON-RPC message over conn, framing it (and
+// gzip-compressing it past compressThreshold) if framing was negotiated for
+// this connection, or falling back to the original newline-delimited write.
+func (c *Client) writeMessage(conn net.Conn, payload []byte) error {
+	c.mu.RLock()
+	framed := c.framed
+	threshold := c.compressThreshold
+	c.mu.RUnlock()
+
+	if framed {
+		return writeFrame(conn, payload, threshold)
+	}
+
+	if _, err := conn.Write(append(payload, '\n')); err != nil {
+		return err
+	}
 	return nil
 }
 
+// Context returns the Client's own lifetime context, canceled by Close.
+// Long-running background consumers (StreamScreenshots started outside an
+// MCP request's own short-lived ctx) tie their lifetime to this instead.
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
 // IsConnected returns current connection state
 func (c *Client) IsConnected() bool {
 	c.mu.RLock()
@@ -89,6 +374,232 @@ func (c *Client) IsConnected() bool {
 	return c.connected
 }
 
+// markDisconnected flags the client as disconnected and drops the socket,
+// so the next Supervise tick (or an explicit Connect) starts fresh.
+func (c *Client) markDisconnected() {
+	c.mu.Lock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.conn = nil
+	c.reader = nil
+	c.connected = false
+	c.mu.Unlock()
+
+	c.failPendingRequests()
+}
+
+// failPendingRequests answers every in-flight sendRequest call with
+// errDisconnected instead of leaving it to stall until its own 30s timeout,
+// so a mid-session editor restart surfaces immediately - except calls made
+// via SendIdempotentRequest, which are moved to replayQueue instead so
+// Supervise can reissue them once reconnected.
+func (c *Client) failPendingRequests() {
+	c.pendingMu.Lock()
+	var toReplay []replayEntry
+	for id, ch := range c.pending {
+		if info, ok := c.replayable[id]; ok {
+			toReplay = append(toReplay, replayEntry{ctx: info.ctx, method: info.method, params: info.params, ch: ch})
+			delete(c.replayable, id)
+			continue
+		}
+		ch <- &Response{JSONRPC: jsonRPCVersion, ID: id, Error: errDisconnected}
+	}
+	c.pendingMu.Unlock()
+
+	if len(toReplay) == 0 {
+		return
+	}
+	c.replayQueueMu.Lock()
+	c.replayQueue = append(c.replayQueue, toReplay...)
+	c.replayQueueMu.Unlock()
+}
+
+// replayPending reissues every request stashed by failPendingRequests with a
+// fresh ID, resolving each original caller's channel with whatever response
+// (or failure) the reissued call gets. Called by Supervise right after a
+// successful reconnect.
+func (c *Client) replayPending() {
+	c.replayQueueMu.Lock()
+	queue := c.replayQueue
+	c.replayQueue = nil
+	c.replayQueueMu.Unlock()
+
+	for _, entry := range queue {
+		entry := entry
+		go func() {
+			resp, err := c.sendRequest(entry.ctx, entry.method, entry.params)
+			if err != nil {
+				resp = &Response{JSONRPC: jsonRPCVersion, Error: &ResponseError{Code: -32000, Message: fmt.Sprintf("replay failed: %v", err)}}
+			}
+			select {
+			case entry.ch <- resp:
+			default:
+			}
+		}()
+	}
+}
+
+func (c *Client) setLastErr(err error) {
+	c.statusMu.Lock()
+	c.lastErr = err
+	c.statusMu.Unlock()
+}
+
+// LastError returns the most recent connect/keepalive failure, or nil if
+// the last attempt succeeded (or none has happened yet).
+func (c *Client) LastError() error {
+	c.statusMu.RLock()
+	defer c.statusMu.RUnlock()
+	return c.lastErr
+}
+
+// Status is the structured connection health the godot_status MCP tool reports.
+type Status struct {
+	Connected  bool       `json:"connected"`
+	SocketPath string     `json:"socket_path"`
+	LastError  string     `json:"last_error,omitempty"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"`
+}
+
+// Status reports current connection health for the godot_status tool.
+func (c *Client) Status() Status {
+	status := Status{Connected: c.IsConnected(), SocketPath: c.socketPath}
+	if lastSeen := c.LastSeen(); !lastSeen.IsZero() {
+		status.LastSeen = &lastSeen
+	}
+	if err := c.LastError(); err != nil {
+		status.LastError = err.Error()
+	}
+	return status
+}
+
+// EditorOfflineError is returned by WaitUntilConnected when the editor
+// hasn't reconnected within the caller's deadline, so a tool handler can
+// surface a retry hint instead of a bare socket error.
+type EditorOfflineError struct {
+	SocketPath string
+	LastErr    error
+}
+
+func (e *EditorOfflineError) Error() string {
+	msg := fmt.Sprintf("godot editor not connected at %s; start/reconnect the editor and retry", e.SocketPath)
+	if e.LastErr != nil {
+		msg += fmt.Sprintf(" (last error: %v)", e.LastErr)
+	}
+	return msg
+}
+
+// WaitUntilConnected blocks until the client is connected, ctx is done, or
+// timeout elapses, whichever comes first. A live Supervise goroutine is
+// what actually reconnects; this just waits for it (or for Connect having
+// already been called) to land.
+func (c *Client) WaitUntilConnected(ctx context.Context, timeout time.Duration) error {
+	if c.IsConnected() {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if c.IsConnected() {
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return &EditorOfflineError{SocketPath: c.socketPath, LastErr: c.LastError()}
+			}
+		}
+	}
+}
+
+// Supervise keeps the client connected for the lifetime of ctx: it connects
+// with jittered exponential backoff (initialReconnectBackoff up to
+// MaxReconnectBackoff), then pings the editor every HeartbeatInterval so a
+// hung plugin (process alive, socket half-open) is detected and reconnected
+// rather than surfacing as a failure on the next tool call. A single failed
+// ping is tolerated - maxConsecutiveHeartbeatFailures in a row, or LastSeen
+// falling more than 2*interval behind, is what actually triggers a
+// reconnect. Only the first call on a Client supervises; later calls
+// return immediately.
+func (c *Client) Supervise(ctx context.Context) {
+	c.statusMu.Lock()
+	if c.supervisorStarted {
+		c.statusMu.Unlock()
+		return
+	}
+	c.supervisorStarted = true
+	c.statusMu.Unlock()
+
+	backoff := initialReconnectBackoff
+	consecutiveFailures := 0
+	everConnected := false
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !c.IsConnected() {
+			if err := c.Connect(ctx); err != nil {
+				c.setLastErr(err)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(jitter(backoff)):
+				}
+				backoff *= 2
+				if backoff > MaxReconnectBackoff {
+					backoff = MaxReconnectBackoff
+				}
+				continue
+			}
+			c.setLastErr(nil)
+			backoff = initialReconnectBackoff
+			consecutiveFailures = 0
+
+			if everConnected {
+				c.dispatchEvent("reconnected", nil)
+				c.replayPending()
+			}
+			everConnected = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.HeartbeatInterval()):
+			if !c.IsConnected() {
+				continue
+			}
+
+			if err := c.HealthCheck(ctx); err != nil {
+				consecutiveFailures++
+				c.setLastErr(fmt.Errorf("heartbeat failed: %w", err))
+			} else {
+				consecutiveFailures = 0
+				c.setLastErr(nil)
+			}
+
+			stale := !c.LastSeen().IsZero() && time.Since(c.LastSeen()) > 2*c.HeartbeatInterval()
+			if consecutiveFailures >= maxConsecutiveHeartbeatFailures || stale {
+				c.markDisconnected()
+				consecutiveFailures = 0
+			}
+		}
+	}
+}
+
+// jitter returns d plus up to 20% extra, so many clients reconnecting after
+// an editor restart don't all retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
 // Close shuts down the client
 func (c *Client) Close() error {
 	c.cancel()
@@ -108,6 +619,7 @@ func (c *Client) readLoop() {
 		c.mu.Lock()
 		c.connected = false
 		c.mu.Unlock()
+		c.failPendingRequests()
 	}()
 
 	for {
@@ -119,23 +631,31 @@ func (c *Client) readLoop() {
 
 		c.mu.RLock()
 		reader := c.reader
+		framed := c.framed
 		c.mu.RUnlock()
 
 		if reader == nil {
 			return
 		}
 
-		// read one line (newline-delimited JSON)
-		if !reader.Scan() {
-			if err := reader.Err(); err != nil {
-				if c.ctx.Err() == nil {
-					log.Printf("[godot] Read error: %v", err)
-				}
+		var data []byte
+		var err error
+		if framed {
+			data, err = readFrame(reader)
+		} else {
+			// legacy newline-delimited JSON, for a peer that didn't accept
+			// the framed hello in negotiateFraming
+			data, err = reader.ReadBytes('\n')
+			data = bytes.TrimRight(data, "\n")
+		}
+
+		if err != nil {
+			if c.ctx.Err() == nil {
+				log.Printf("[godot] Read error: %v", err)
 			}
 			return
 		}
 
-		data := reader.Bytes()
 		if len(data) == 0 {
 			continue
 		}
@@ -144,8 +664,31 @@ func (c *Client) readLoop() {
 	}
 }
 
-// handleMessage processes a raw message
+// handleMessage processes a raw message, which may be a single JSON-RPC
+// object or a batch (JSON array of objects, dispatched element-by-element).
 func (c *Client) handleMessage(data []byte) {
+	trimmed := data
+	for len(trimmed) > 0 && (trimmed[0] == ' ' || trimmed[0] == '\t') {
+		trimmed = trimmed[1:]
+	}
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(data, &batch); err != nil {
+			log.Printf("[godot] Failed to parse batch message: %v", err)
+			return
+		}
+		for _, elem := range batch {
+			c.handleOne(elem)
+		}
+		return
+	}
+	c.handleOne(data)
+}
+
+// handleOne processes a single JSON-RPC object: a response (has id), or a
+// notification (no id), which is fanned out to any SubscribeMethod-ers of its
+// method and any Subscribe-rs of its topic.
+func (c *Client) handleOne(data []byte) {
 	log.Printf("[godot] Received message: %s", string(data)[:min(len(data), 200)])
 
 	// try to parse as response (has id)
@@ -159,6 +702,7 @@ func (c *Client) handleMessage(data []byte) {
 
 	if err := json.Unmarshal(data, &msg); err != nil {
 		log.Printf("[godot] Failed to parse message: %v", err)
+		c.logEvent(LevelError, "", 0, "decode_failed", map[string]interface{}{"error": err.Error()})
 		return
 	}
 
@@ -191,13 +735,193 @@ func (c *Client) handleMessage(data []byte) {
 		return
 	}
 
-	// else it's a notification
+	// else it's a notification: keep the legacy output-buffer path for
+	// backwards compatibility, and fan out to any SubscribeMethod-ers of
+	// this method as well as any Subscribe-rs of its normalized topic
 	if msg.Method == "output" {
 		var out OutputNotification
 		if err := json.Unmarshal(msg.Params, &out); err == nil {
 			c.addOutput(out)
 		}
 	}
+	c.dispatchNotification(msg.Method, msg.Params)
+	c.dispatchEvent(eventTopic(msg.Method), msg.Params)
+}
+
+// methodSub is one SubscribeMethod registration: ch receives every
+// Notification for method, and dropped counts the ones that didn't fit
+// because ch's buffer was full (see DroppedCount).
+type methodSub struct {
+	method  string
+	ch      chan Notification
+	dropped atomic.Uint64
+}
+
+// dispatchNotification delivers a server-initiated notification to every
+// subscriber registered for that method via SubscribeMethod. Sends are
+// non-blocking: a full subscriber channel drops the notification and
+// increments its dropped counter rather than stalling readLoop - this
+// mirrors the message-dispatcher pattern used by karyon-go's JSON-RPC
+// client.
+func (c *Client) dispatchNotification(method string, params json.RawMessage) {
+	c.subsMu.Lock()
+	subs := c.subs[method]
+	matched := make([]*methodSub, 0, len(subs))
+	for _, sub := range subs {
+		matched = append(matched, sub)
+	}
+	c.subsMu.Unlock()
+
+	if len(matched) == 0 {
+		return
+	}
+
+	note := Notification{Method: method, Params: params}
+	for _, sub := range matched {
+		select {
+		case sub.ch <- note:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// SubscribeMethod registers interest in server-initiated notifications by
+// their raw wire method name (e.g. "output", "debugger_paused"), for
+// callers (MCP tools, log tailers, tests) that want the untranslated
+// params rather than an Event's normalized topic; Subscribe, which groups
+// related methods under stable topic names, is preferred for new code.
+// buffer sizes ch (0 uses a sensible default); call Unsubscribe with the
+// returned id to stop receiving and release the channel.
+func (c *Client) SubscribeMethod(method string, buffer int) (id uint64, ch <-chan Notification) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+
+	sub := &methodSub{method: method, ch: make(chan Notification, buffer)}
+
+	c.subsMu.Lock()
+	if c.subs[method] == nil {
+		c.subs[method] = make(map[uint64]*methodSub)
+	}
+	subID := c.nextSubID
+	c.nextSubID++
+	c.subs[method][subID] = sub
+	c.subsByID[subID] = sub
+	c.subsMu.Unlock()
+
+	return subID, sub.ch
+}
+
+// Unsubscribe releases a subscription returned by SubscribeMethod. Unknown
+// ids (already unsubscribed, or never valid) are a no-op.
+func (c *Client) Unsubscribe(id uint64) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	sub, ok := c.subsByID[id]
+	if !ok {
+		return
+	}
+	delete(c.subsByID, id)
+	delete(c.subs[sub.method], id)
+}
+
+// DroppedCount returns how many notifications a SubscribeMethod
+// subscription has dropped because its buffer was full, or 0 if id is
+// unknown.
+func (c *Client) DroppedCount(id uint64) uint64 {
+	c.subsMu.Lock()
+	sub, ok := c.subsByID[id]
+	c.subsMu.Unlock()
+	if !ok {
+		return 0
+	}
+	return sub.dropped.Load()
+}
+
+// SendNotification sends a fire-and-forget JSON-RPC 2.0 notification (a
+// request with no id) and returns as soon as it's written; Godot is not
+// expected to reply.
+func (c *Client) SendNotification(ctx context.Context, method string, params interface{}) error {
+	c.mu.RLock()
+	conn := c.conn
+	connected := c.connected
+	c.mu.RUnlock()
+
+	if !connected || conn == nil {
+		return fmt.Errorf("not connected to Godot")
+	}
+
+	data, err := json.Marshal(newRequest(0, method, params))
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := c.writeMessage(conn, data); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+// SendBatch marshals calls as a single JSON-RPC 2.0 batch array and waits
+// for all responses, dispatched concurrently on the Godot side and
+// correlated back to each call by id.
+func (c *Client) SendBatch(ctx context.Context, calls []Request) ([]Response, error) {
+	c.mu.RLock()
+	conn := c.conn
+	connected := c.connected
+	c.mu.RUnlock()
+
+	if !connected || conn == nil {
+		return nil, fmt.Errorf("not connected to Godot")
+	}
+
+	batch := make(Batch, len(calls))
+	chans := make([]chan *Response, len(calls))
+	for i, call := range calls {
+		id := nextID()
+		call.ID = id
+		call.JSONRPC = jsonRPCVersion
+		batch[i] = call
+
+		ch := make(chan *Response, 1)
+		chans[i] = ch
+		c.pendingMu.Lock()
+		c.pending[id] = ch
+		c.pendingMu.Unlock()
+	}
+	defer func() {
+		c.pendingMu.Lock()
+		for _, call := range batch {
+			delete(c.pending, call.ID)
+		}
+		c.pendingMu.Unlock()
+	}()
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("marshal batch: %w", err)
+	}
+
+	if err := c.writeMessage(conn, data); err != nil {
+		return nil, fmt.Errorf("write: %w", err)
+	}
+
+	results := make([]Response, len(calls))
+	for i, ch := range chans {
+		select {
+		case resp := <-ch:
+			results[i] = *resp
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.deadlineCancelCh():
+			return nil, errCanceled
+		case <-time.After(30 * time.Second):
+			return nil, fmt.Errorf("batch request timed out")
+		}
+	}
+	return results, nil
 }
 
 // addOutput adds to output buffer
@@ -255,8 +979,60 @@ func writeOverrides(overrides Overrides) error {
 	return nil
 }
 
+// SetRecorder attaches r so every request/response this client exchanges
+// with Godot is also appended to a --record capture file, for later
+// --replay or as a unit-test fixture. Pass nil to stop recording.
+func (c *Client) SetRecorder(r *Recorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorder = r
+}
+
+// recorderFor returns the currently attached recorder, if any.
+func (c *Client) recorderFor() *Recorder {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.recorder
+}
+
+// replayInfo captures what's needed to reissue a request that was in
+// flight via SendIdempotentRequest when the connection dropped.
+type replayInfo struct {
+	ctx    context.Context
+	method string
+	params interface{}
+}
+
+// replayEntry is a replayInfo paired with the original caller's response
+// channel, queued by failPendingRequests for replayPending to drain.
+type replayEntry struct {
+	ctx    context.Context
+	method string
+	params interface{}
+	ch     chan *Response
+}
+
 // sendRequest sends a request and waits for response
 func (c *Client) sendRequest(ctx context.Context, method string, params interface{}) (*Response, error) {
+	return c.doSendRequest(ctx, method, params, false)
+}
+
+// SendIdempotentRequest behaves like an ordinary RPC call, except that if
+// the connection drops before Godot replies, the request isn't failed with
+// errDisconnected: it's stashed, and Supervise reissues it with a fresh ID
+// once reconnected, resolving this call with that reply instead. Only use
+// this for requests safe to run twice (e.g. read-only queries) - a
+// side-effecting call could otherwise run twice, if Godot's original reply
+// was merely delayed rather than lost.
+func (c *Client) SendIdempotentRequest(ctx context.Context, method string, params interface{}) (*Response, error) {
+	return c.doSendRequest(ctx, method, params, true)
+}
+
+func (c *Client) doSendRequest(ctx context.Context, method string, params interface{}, replayable bool) (*Response, error) {
+	if c.replay != nil {
+		return c.sendRequestReplay(method, params)
+	}
+
 	c.mu.RLock()
 	conn := c.conn
 	connected := c.connected
@@ -267,48 +1043,82 @@ func (c *Client) sendRequest(ctx context.Context, method string, params interfac
 	}
 
 	id := nextID()
-	req := Request{
-		ID:     id,
-		Method: method,
-		Params: params,
-	}
+	req := newRequest(id, method, params)
 
 	data, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshal: %w", err)
 	}
 
-	// add newline delimiter for line-based protocol
-	data = append(data, '\n')
-
 	// register pending request
 	respCh := make(chan *Response, 1)
 	c.pendingMu.Lock()
 	c.pending[id] = respCh
+	if replayable {
+		c.replayable[id] = replayInfo{ctx: ctx, method: method, params: params}
+	}
 	c.pendingMu.Unlock()
 
 	defer func() {
 		c.pendingMu.Lock()
 		delete(c.pending, id)
+		delete(c.replayable, id)
 		c.pendingMu.Unlock()
 	}()
 
+	start := time.Now()
+	c.logEvent(LevelDebug, method, id, "request_sent", map[string]interface{}{"params_size": len(data)})
+	if rec := c.recorderFor(); rec != nil {
+		paramsJSON, _ := json.Marshal(params)
+		rec.record(RecordedEvent{Direction: "request", ID: id, Method: method, Params: paramsJSON})
+	}
+
 	// send message
-	if _, err := conn.Write(data); err != nil {
+	if err := c.writeMessage(conn, data); err != nil {
+		c.markDisconnected()
 		return nil, fmt.Errorf("write: %w", err)
 	}
 
-	// wait for response
+	// wait for response, the client-wide deadline (SetDeadline), or the
+	// caller's own context/timeout, whichever comes first
 	select {
 	case resp := <-respCh:
+		c.touchLastSeen()
+		fields := map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()}
+		level := LevelDebug
+		if resp.Error != nil {
+			level = LevelWarn
+			fields["error_code"] = resp.Error.Code
+		}
+		c.logEvent(level, method, id, "response_received", fields)
+		if rec := c.recorderFor(); rec != nil {
+			rec.record(RecordedEvent{Direction: "response", ID: id, Method: method, Result: resp.Result, Error: resp.Error})
+		}
 		return resp, nil
 	case <-ctx.Done():
+		c.logEvent(LevelWarn, method, id, "request_canceled", map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()})
 		return nil, ctx.Err()
+	case <-c.deadlineCancelCh():
+		c.logEvent(LevelWarn, method, id, "request_canceled", map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()})
+		return nil, errCanceled
 	case <-time.After(30 * time.Second):
+		c.logEvent(LevelError, method, id, "request_timeout", map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()})
 		return nil, fmt.Errorf("request timed out")
 	}
 }
 
+// sendRequestTimeout is sendRequest with a per-call timeout applied on top of
+// ctx, for handlers that want to bound a single call without affecting the
+// client-wide deadline set via SetDeadline.
+func (c *Client) sendRequestTimeout(ctx context.Context, method string, params interface{}, timeout time.Duration) (*Response, error) {
+	if timeout <= 0 {
+		return c.sendRequest(ctx, method, params)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.sendRequest(ctx, method, params)
+}
+
 // checkStartupErrors waits for the game to initialize, then checks if it
 // crashed on startup (runtime error or parser error). populates result fields
 // if an error is detected and auto-stops the crashed game.
@@ -327,7 +1137,7 @@ func (c *Client) checkStartupErrors(ctx context.Context, result *GenericResult,
 
 	// runtime error: debugger paused on error in _ready() or similar
 	if state.Paused {
-		trace, err := c.GetStackTrace(ctx)
+		trace, err := c.GetStackTrace(ctx, GetStackTraceParams{})
 		if err == nil && trace.Length > 0 {
 			result.ErrorDetected = true
 			result.StackTrace = trace.StackTrace
@@ -342,7 +1152,7 @@ func (c *Client) checkStartupErrors(ctx context.Context, result *GenericResult,
 
 	// game didn't start: parser error or immediate crash
 	if !state.IsPlaying {
-		errors, err := c.GetDebugErrors(ctx)
+		errors, err := c.GetDebugErrors(ctx, GetDebugErrorsParams{})
 		if err == nil && errors.Length > 0 {
 			result.ErrorDetected = true
 			result.StackTrace = errors.Errors
@@ -481,8 +1291,9 @@ func (c *Client) GetOutputFromGodot(ctx context.Context, clear bool, newOnly boo
 	return &result, nil
 }
 
-// GetDebugErrors fetches errors/warnings from debugger
-func (c *Client) GetDebugErrors(ctx context.Context) (*DebugErrorsResult, error) {
+// GetDebugErrors fetches errors/warnings from debugger. See
+// GetDebugErrorsParams for the Simplified option.
+func (c *Client) GetDebugErrors(ctx context.Context, params GetDebugErrorsParams) (*DebugErrorsResult, error) {
 	resp, err := c.sendRequest(ctx, "get_debugger_errors", nil)
 	if err != nil {
 		return nil, err
@@ -497,11 +1308,15 @@ func (c *Client) GetDebugErrors(ctx context.Context) (*DebugErrorsResult, error)
 			return nil, fmt.Errorf("unmarshal result: %w", err)
 		}
 	}
+	if params.Simplified {
+		result.Errors = stack.SimplifyErrors(result.Errors)
+	}
 	return &result, nil
 }
 
-// GetStackTrace fetches stack trace from debugger (populated on runtime errors)
-func (c *Client) GetStackTrace(ctx context.Context) (*StackTraceResult, error) {
+// GetStackTrace fetches stack trace from debugger (populated on runtime
+// errors). See GetStackTraceParams for the Simplified option.
+func (c *Client) GetStackTrace(ctx context.Context, params GetStackTraceParams) (*StackTraceResult, error) {
 	resp, err := c.sendRequest(ctx, "get_debugger_stack_trace", nil)
 	if err != nil {
 		return nil, err
@@ -516,6 +1331,9 @@ func (c *Client) GetStackTrace(ctx context.Context) (*StackTraceResult, error) {
 			return nil, fmt.Errorf("unmarshal result: %w", err)
 		}
 	}
+	if params.Simplified {
+		result.StackTrace = stack.SimplifyStackTrace(result.StackTrace)
+	}
 	return &result, nil
 }
 
@@ -663,12 +1481,17 @@ func (c *Client) GetMonitors(ctx context.Context) (*MonitorsResult, error) {
 	return &result, nil
 }
 
-// SetBreakpoint sets or clears a breakpoint at a specific file:line
-func (c *Client) SetBreakpoint(ctx context.Context, path string, line int, enabled bool) (*GenericResult, error) {
+// SetBreakpoint sets or clears a breakpoint at a specific file:line.
+// condition, hitCondition, and logMessage are tracked in c.Breakpoints and
+// evaluated by ProcessBreakpointPause; pass "" for any not in use.
+func (c *Client) SetBreakpoint(ctx context.Context, path string, line int, enabled bool, condition, hitCondition, logMessage string) (*GenericResult, error) {
 	params := SetBreakpointParams{
-		Path:    path,
-		Line:    line,
-		Enabled: enabled,
+		Path:         path,
+		Line:         line,
+		Enabled:      enabled,
+		Condition:    condition,
+		HitCondition: hitCondition,
+		LogMessage:   logMessage,
 	}
 	resp, err := c.sendRequest(ctx, "set_breakpoint", params)
 	if err != nil {
@@ -678,6 +1501,12 @@ func (c *Client) SetBreakpoint(ctx context.Context, path string, line int, enabl
 		return nil, fmt.Errorf("godot error: %s", resp.Error.Message)
 	}
 
+	if enabled {
+		c.Breakpoints.Set(path, line, condition, hitCondition, logMessage)
+	} else {
+		c.Breakpoints.Remove(path, line)
+	}
+
 	var result GenericResult
 	if resp.Result != nil {
 		if err := json.Unmarshal(*resp.Result, &result); err != nil {
@@ -697,6 +1526,8 @@ func (c *Client) ClearBreakpoints(ctx context.Context) (*GenericResult, error) {
 		return nil, fmt.Errorf("godot error: %s", resp.Error.Message)
 	}
 
+	c.Breakpoints.Clear()
+
 	var result GenericResult
 	if resp.Result != nil {
 		if err := json.Unmarshal(*resp.Result, &result); err != nil {
@@ -706,7 +1537,9 @@ func (c *Client) ClearBreakpoints(ctx context.Context) (*GenericResult, error) {
 	return &result, nil
 }
 
-// GetDebuggerState returns the current debugger state (paused, active, debuggable)
+// GetDebuggerState returns the current debugger state (paused, active,
+// debuggable), plus the client-tracked conditional/logpoint breakpoints and
+// their hit counts.
 func (c *Client) GetDebuggerState(ctx context.Context) (*DebuggerStateResult, error) {
 	resp, err := c.sendRequest(ctx, "get_debugger_state", nil)
 	if err != nil {
@@ -722,9 +1555,65 @@ func (c *Client) GetDebuggerState(ctx context.Context) (*DebuggerStateResult, er
 			return nil, fmt.Errorf("unmarshal result: %w", err)
 		}
 	}
+	result.ConditionalBreakpoints = c.Breakpoints.List()
 	return &result, nil
 }
 
+// ProcessBreakpointPause is called by a caller (the DAP state pump, or a
+// tool handler) when the debugger reports a pause at path:line. It evaluates
+// any tracked condition/hit-condition/logpoint for that location and decides
+// whether the pause should actually surface: a failed condition or
+// unsatisfied hit-condition transparently resumes execution (returns
+// stop=false), as does a logpoint (which also prints its expanded message
+// into the output buffer before resuming). A location with no tracked
+// metadata always stops.
+func (c *Client) ProcessBreakpointPause(ctx context.Context, path string, line int) (stop bool, err error) {
+	info, ok := c.Breakpoints.Get(path, line)
+	if !ok {
+		return true, nil
+	}
+
+	hits := c.Breakpoints.RecordHit(path, line)
+
+	if info.Condition != "" {
+		result, err := c.EvaluateExpression(ctx, info.Condition)
+		if err != nil {
+			return true, fmt.Errorf("evaluate condition: %w", err)
+		}
+		if result.Value != "true" {
+			_, err := c.DebugContinue(ctx)
+			return false, err
+		}
+	}
+
+	if info.HitCondition != "" {
+		satisfied, err := evalHitCondition(info.HitCondition, hits)
+		if err != nil {
+			return true, err
+		}
+		if !satisfied {
+			_, err := c.DebugContinue(ctx)
+			return false, err
+		}
+	}
+
+	if info.LogMessage != "" {
+		expanded := logMessagePlaceholderRe.ReplaceAllStringFunc(info.LogMessage, func(m string) string {
+			expr := m[1 : len(m)-1]
+			result, err := c.EvaluateExpression(ctx, expr)
+			if err != nil {
+				return m
+			}
+			return result.Value
+		})
+		c.addOutput(OutputNotification{Type: "logpoint", Message: expanded})
+		_, err := c.DebugContinue(ctx)
+		return false, err
+	}
+
+	return true, nil
+}
+
 // DebugContinue resumes execution after hitting a breakpoint
 func (c *Client) DebugContinue(ctx context.Context) (*GenericResult, error) {
 	resp, err := c.sendRequest(ctx, "debug_continue", nil)
@@ -786,47 +1675,30 @@ func (c *Client) DebugBreak(ctx context.Context) (*GenericResult, error) {
 // UDP port for game autoload (peek_runtime_helper.gd)
 const GameUDPPort = 6971
 
-// sendGameUDP sends a request directly to the game autoload via UDP
-// bypasses C++ extension for game-side operations
+// sendGameUDP sends a request directly to the game autoload via UDP,
+// bypassing the C++ extension for game-side operations. The request and
+// its reply are carried over the chunked/reliable fragment layer in
+// game_udp.go so neither side is limited to a single datagram (see
+// GetGameScreenshot) and concurrent callers share one socket without
+// racing each other.
 func sendGameUDP(ctx context.Context, request interface{}) ([]byte, error) {
 	data, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	// resolve UDP address
-	addr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("127.0.0.1:%d", GameUDPPort))
-	if err != nil {
-		return nil, fmt.Errorf("resolve udp addr: %w", err)
-	}
-
-	// create UDP connection
-	conn, err := net.DialUDP("udp", nil, addr)
+	conn, err := sharedGameUDPConn()
 	if err != nil {
-		return nil, fmt.Errorf("dial udp: %w", err)
-	}
-	defer conn.Close()
-
-	// set deadline based on context or default timeout
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		deadline = time.Now().Add(5 * time.Second)
-	}
-	conn.SetDeadline(deadline)
-
-	// send request
-	if _, err := conn.Write(data); err != nil {
-		return nil, fmt.Errorf("write udp: %w", err)
+		return nil, err
 	}
 
-	// read response
-	buf := make([]byte, 65535)
-	n, err := conn.Read(buf)
-	if err != nil {
-		return nil, fmt.Errorf("read udp: %w", err)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
 	}
 
-	return buf[:n], nil
+	return conn.send(ctx, data)
 }
 
 // EvaluateExpression evaluates a GDScript expression in the running game
@@ -911,3 +1783,147 @@ func (c *Client) SendInput(ctx context.Context, inputType string, params map[str
 
 	return &result, nil
 }
+
+// SendInputSequence dispatches an ordered batch of input events as a single
+// UDP round-trip, so scripted interactions (e.g. typing into a LineEdit)
+// don't need one call per keystroke.
+func (c *Client) SendInputSequence(ctx context.Context, steps []InputSequenceStep) (*InputSequenceResult, error) {
+	request := map[string]interface{}{
+		"cmd":   "input_sequence",
+		"steps": steps,
+	}
+
+	respData, err := sendGameUDP(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("udp request failed: %w", err)
+	}
+
+	var result InputSequenceResult
+	if err := json.Unmarshal(respData, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if result.Error != "" {
+		return nil, fmt.Errorf("input_sequence error: %s", result.Error)
+	}
+
+	return &result, nil
+}
+
+// StartInputRecording tells the game autoload to begin capturing live
+// InputEvents (direct UDP) for later replay via SendInputSequence.
+func (c *Client) StartInputRecording(ctx context.Context, params StartInputRecordingParams) (*StartInputRecordingResult, error) {
+	request := map[string]interface{}{
+		"cmd":                  "start_input_recording",
+		"event_types":          params.EventTypes,
+		"node_path":            params.NodePath,
+		"max_duration_seconds": params.MaxDurationSeconds,
+	}
+
+	respData, err := sendGameUDP(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("udp request failed: %w", err)
+	}
+
+	var result StartInputRecordingResult
+	if err := json.Unmarshal(respData, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if result.Error != "" {
+		return nil, fmt.Errorf("start_input_recording error: %s", result.Error)
+	}
+
+	return &result, nil
+}
+
+// StopInputRecording ends an in-progress recording and returns the captured
+// events, optionally persisting them to savePath (project-relative) on the
+// Godot side.
+func (c *Client) StopInputRecording(ctx context.Context, savePath string) (*StopInputRecordingResult, error) {
+	request := map[string]interface{}{
+		"cmd":       "stop_input_recording",
+		"save_path": savePath,
+	}
+
+	respData, err := sendGameUDP(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("udp request failed: %w", err)
+	}
+
+	var result StopInputRecordingResult
+	if err := json.Unmarshal(respData, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if result.Error != "" {
+		return nil, fmt.Errorf("stop_input_recording error: %s", result.Error)
+	}
+
+	return &result, nil
+}
+
+// shiftedKeycodes maps a printable rune to the base US-layout key that
+// produces it when held with Shift (e.g. '!' is Shift+1).
+var shiftedKeycodes = map[rune]string{
+	'!': "1", '@': "2", '#': "3", '$': "4", '%': "5",
+	'^': "6", '&': "7", '*': "8", '(': "9", ')': "0",
+	'_': "MINUS", '+': "EQUAL", '{': "BRACKETLEFT", '}': "BRACKETRIGHT",
+	'|': "BACKSLASH", ':': "SEMICOLON", '"': "APOSTROPHE",
+	'<': "COMMA", '>': "PERIOD", '?': "SLASH", '~': "QUOTELEFT",
+}
+
+// unshiftedKeycodes maps punctuation runes that don't need Shift.
+var unshiftedKeycodes = map[rune]string{
+	'-': "MINUS", '=': "EQUAL", '[': "BRACKETLEFT", ']': "BRACKETRIGHT",
+	'\\': "BACKSLASH", ';': "SEMICOLON", '\'': "APOSTROPHE",
+	',': "COMMA", '.': "PERIOD", '/': "SLASH", '`': "QUOTELEFT",
+}
+
+// keyForRune returns the keycode name for r and whether Shift must be held
+// to produce it, or ok=false if r has no known mapping.
+func keyForRune(r rune) (keycode string, shift bool, ok bool) {
+	switch {
+	case r == ' ':
+		return "SPACE", false, true
+	case r == '\n':
+		return "ENTER", false, true
+	case r == '\t':
+		return "TAB", false, true
+	case r >= 'a' && r <= 'z':
+		return strings.ToUpper(string(r)), false, true
+	case r >= 'A' && r <= 'Z':
+		return string(r), true, true
+	case r >= '0' && r <= '9':
+		return string(r), false, true
+	}
+	if keycode, ok := shiftedKeycodes[r]; ok {
+		return keycode, true, true
+	}
+	if keycode, ok := unshiftedKeycodes[r]; ok {
+		return keycode, false, true
+	}
+	return "", false, false
+}
+
+// ExpandTextInput expands text into key press/release steps, bracketing
+// shifted characters with explicit SHIFT down/up steps since Godot has no
+// "type this string" primitive of its own.
+func ExpandTextInput(text string) []InputSequenceStep {
+	var steps []InputSequenceStep
+	for _, r := range text {
+		keycode, shift, ok := keyForRune(r)
+		if !ok {
+			continue // no keyboard mapping for this rune, skip it
+		}
+		if shift {
+			steps = append(steps, InputSequenceStep{Type: "key", Keycode: "SHIFT", Pressed: true})
+		}
+		steps = append(steps, InputSequenceStep{Type: "key", Keycode: keycode, Pressed: true})
+		steps = append(steps, InputSequenceStep{Type: "key", Keycode: keycode, Pressed: false})
+		if shift {
+			steps = append(steps, InputSequenceStep{Type: "key", Keycode: "SHIFT", Pressed: false})
+		}
+	}
+	return steps
+}
@@ -19,7 +19,7 @@ func newTestClient(t *testing.T) (*Client, net.Conn) {
 	client := NewClient("test")
 	serverConn, clientConn := net.Pipe()
 	client.conn = clientConn
-	client.reader = bufio.NewScanner(clientConn)
+	client.reader = bufio.NewReader(clientConn)
 	client.connected = true
 	go client.readLoop()
 	return client, serverConn
@@ -311,6 +311,118 @@ func TestSendRequest_ContextCancel(t *testing.T) {
 	}
 }
 
+// --- disconnect / replay ---
+
+func TestSendRequest_NonIdempotentFailsOnDisconnect(t *testing.T) {
+	client, serverConn := newTestClient(t)
+	defer client.Close()
+
+	go func() {
+		buf := make([]byte, 4096)
+		serverConn.Read(buf)
+	}()
+
+	ctx := context.Background()
+	done := make(chan *Response, 1)
+	go func() {
+		resp, _ := client.sendRequest(ctx, "get_output", nil)
+		done <- resp
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	serverConn.Close()
+
+	select {
+	case resp := <-done:
+		if resp == nil || resp.Error == nil {
+			t.Fatalf("expected errDisconnected, got %v", resp)
+		}
+		if resp.Error.Code != errDisconnected.Code {
+			t.Errorf("expected error code %d, got %d", errDisconnected.Code, resp.Error.Code)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("sendRequest didn't return after disconnect")
+	}
+}
+
+func TestSendIdempotentRequest_ReplaysAfterReconnect(t *testing.T) {
+	client, serverConn1 := newTestClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	resultCh := make(chan *Response, 1)
+	go func() {
+		resp, err := client.SendIdempotentRequest(ctx, "get_output", nil)
+		if err != nil {
+			t.Errorf("SendIdempotentRequest: %v", err)
+			return
+		}
+		resultCh <- resp
+	}()
+
+	// drain (but never answer) the write, then drop the connection mid-request
+	go func() {
+		buf := make([]byte, 4096)
+		serverConn1.Read(buf)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	serverConn1.Close()
+
+	// wait for readLoop's disconnect path to queue the request for replay
+	deadline := time.Now().Add(time.Second)
+	for {
+		client.replayQueueMu.Lock()
+		queued := len(client.replayQueue)
+		client.replayQueueMu.Unlock()
+		if queued == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 1 queued replay entry, got %d", queued)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// simulate reconnecting to a fresh connection
+	serverConn2, clientConn2 := net.Pipe()
+	defer serverConn2.Close()
+	client.mu.Lock()
+	client.conn = clientConn2
+	client.reader = bufio.NewReader(clientConn2)
+	client.connected = true
+	client.mu.Unlock()
+	go client.readLoop()
+
+	go func() {
+		scanner := bufio.NewScanner(serverConn2)
+		if !scanner.Scan() {
+			return
+		}
+		var req Request
+		json.Unmarshal(scanner.Bytes(), &req)
+		resp := fmt.Sprintf(`{"id":%d,"result":{"output":"replayed","length":8,"total_length":8}}`, req.ID)
+		serverConn2.Write([]byte(resp + "\n"))
+	}()
+
+	client.replayPending()
+
+	select {
+	case resp := <-resultCh:
+		if resp.Error != nil {
+			t.Fatalf("expected no error, got %v", resp.Error)
+		}
+		var result OutputResult
+		if err := json.Unmarshal(*resp.Result, &result); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if result.Output != "replayed" {
+			t.Errorf("expected 'replayed', got %s", result.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for replayed response")
+	}
+}
+
 // --- writeOverrides ---
 
 func TestWriteOverrides_WritesFile(t *testing.T) {
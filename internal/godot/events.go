@@ -0,0 +1,117 @@
+package godot
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Event is one topic-normalized notification delivered by Subscribe.
+// Godot's wire-level notification methods (e.g. "debugger_paused") are
+// mapped to stable topic names by eventTopic so callers don't need to
+// track method-name changes on the GDScript side.
+type Event struct {
+	Topic     string          `json:"topic"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// eventTopics maps a notification's wire method name to the topic name
+// Subscribe callers ask for. Extending the GDScript-side protocol with a
+// new notification just needs an entry here; an unmapped method name is
+// passed through as its own topic so new notifications are observable
+// before this table catches up.
+var eventTopics = map[string]string{
+	"output":           "output",
+	"debugger_paused":  "debugger.paused",
+	"debugger_resumed": "debugger.resumed",
+	"scene_started":    "scene.started",
+	"scene_stopped":    "scene.stopped",
+	"error":            "error",
+
+	// reconnected is synthetic: Supervise dispatches it directly (not via
+	// eventTopic) once a dropped connection comes back, so it's listed here
+	// only to reserve the topic name against a future Godot-side method.
+	"reconnected": "reconnected",
+}
+
+// eventTopic normalizes method to its Subscribe topic name.
+func eventTopic(method string) string {
+	if topic, ok := eventTopics[method]; ok {
+		return topic
+	}
+	return method
+}
+
+// eventSub is one Subscribe call's registration: ch receives every Event
+// whose topic is in topics.
+type eventSub struct {
+	topics map[string]bool
+	ch     chan Event
+}
+
+// dispatchEvent fans params out, as an Event, to every subscriber
+// registered for topic. Sends are non-blocking per subscriber: a full
+// channel drops the event rather than stalling readLoop.
+func (c *Client) dispatchEvent(topic string, params json.RawMessage) {
+	c.eventSubsMu.Lock()
+	var chans []chan Event
+	for _, sub := range c.eventSubs {
+		if sub.topics[topic] {
+			chans = append(chans, sub.ch)
+		}
+	}
+	c.eventSubsMu.Unlock()
+
+	if len(chans) == 0 {
+		return
+	}
+
+	ev := Event{Topic: topic, Data: params, Timestamp: time.Now()}
+	for _, ch := range chans {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// eventSubBuffer is the per-subscriber channel size for Subscribe; a slow
+// consumer drops events past this rather than stalling readLoop.
+const eventSubBuffer = 16
+
+// Subscribe registers interest in one or more normalized topics ("output",
+// "debugger.paused", "debugger.resumed", "scene.started", "scene.stopped",
+// "error") so MCP tools can stream editor state transitions instead of
+// polling GetDebuggerState. The subscription is released either by calling
+// the returned cancel func or by ctx being done, whichever comes first.
+func (c *Client) Subscribe(ctx context.Context, topics ...string) (<-chan Event, func()) {
+	topicSet := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		topicSet[t] = true
+	}
+	sub := &eventSub{topics: topicSet, ch: make(chan Event, eventSubBuffer)}
+
+	c.eventSubsMu.Lock()
+	id := c.nextEventSubID
+	c.nextEventSubID++
+	c.eventSubs[id] = sub
+	c.eventSubsMu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			c.eventSubsMu.Lock()
+			delete(c.eventSubs, id)
+			c.eventSubsMu.Unlock()
+		})
+	}
+
+	go func() {
+		<-ctx.Done()
+		cancel()
+	}()
+
+	return sub.ch, cancel
+}
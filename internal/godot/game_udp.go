@@ -0,0 +1,265 @@
+package godot
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Reliable-datagram layer over the single UDP socket sendGameUDP uses to
+// reach the game autoload. A single Go->game command may not fit in one
+// datagram (a screenshot response, a long scene dump), and UDP drops
+// packets, so every datagram carries a small header that lets the two
+// sides fragment, reassemble, and retry:
+//
+//	{request_id uint32, seq uint16, total uint16, flags uint8}
+//
+// The game autoload echoes request_id back on every fragment of its reply
+// so concurrent calls sharing the one socket (GetGameScreenshot racing
+// SendInput, say) can be demultiplexed without stepping on each other.
+const (
+	// maxGameUDPPayload is the body size per fragment, comfortably under a
+	// typical network's MTU once the header and UDP/IP overhead are added.
+	maxGameUDPPayload = 1200
+
+	gameUDPHeaderSize = 4 + 2 + 2 + 1 // request_id + seq + total + flags
+
+	gameUDPMaxRetries      = 3
+	gameUDPFragmentTimeout = 200 * time.Millisecond
+
+	// udpFlagNAK marks a datagram as a Go->game retransmit request whose
+	// body is a packed list of missing seq (uint16) values, rather than a
+	// fragment of the command itself.
+	udpFlagNAK byte = 1 << 0
+)
+
+// gameUDPPending tracks the fragments received so far for one outstanding
+// request_id, and is signaled (non-blockingly) every time a new fragment
+// completes the set.
+type gameUDPPending struct {
+	mu    sync.Mutex
+	total uint16
+	frags map[uint16][]byte
+	done  chan struct{}
+}
+
+func newGameUDPPending() *gameUDPPending {
+	return &gameUDPPending{frags: make(map[uint16][]byte), done: make(chan struct{}, 1)}
+}
+
+// addFragment records one received fragment and reports whether the full
+// set (as declared by total) is now present.
+func (p *gameUDPPending) addFragment(seq, total uint16, body []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+	p.frags[seq] = append([]byte(nil), body...)
+	return uint16(len(p.frags)) >= p.total && p.total > 0
+}
+
+// missing reports the seqs not yet received and whether the set is complete.
+// total==0 means no fragment has arrived yet (total is only learned from a
+// received fragment's header), so that case is reported as incomplete with
+// no known gaps rather than as a spuriously "complete" empty set.
+func (p *gameUDPPending) missing() (gaps []uint16, complete bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.total == 0 {
+		return nil, false
+	}
+	for seq := uint16(0); seq < p.total; seq++ {
+		if _, ok := p.frags[seq]; !ok {
+			gaps = append(gaps, seq)
+		}
+	}
+	return gaps, len(gaps) == 0
+}
+
+// assemble concatenates fragments 0..total-1 in order. Call only once
+// missing() reports the set complete.
+func (p *gameUDPPending) assemble() []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var buf bytes.Buffer
+	for seq := uint16(0); seq < p.total; seq++ {
+		buf.Write(p.frags[seq])
+	}
+	return buf.Bytes()
+}
+
+// gameUDPConn is the process-wide shared socket every sendGameUDP call
+// writes to and reads from; dialing a fresh socket per call (the previous
+// approach) made concurrent calls race on separate local ports with no way
+// for the game autoload to tell them apart.
+type gameUDPConn struct {
+	conn   *net.UDPConn
+	nextID atomic.Uint32
+
+	mu      sync.Mutex
+	pending map[uint32]*gameUDPPending
+}
+
+var (
+	gameUDPOnce   sync.Once
+	sharedGameUDP *gameUDPConn
+	gameUDPErr    error
+)
+
+// sharedGameUDPConn dials (once) and returns the process-wide UDP socket.
+func sharedGameUDPConn() (*gameUDPConn, error) {
+	gameUDPOnce.Do(func() {
+		addr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("127.0.0.1:%d", GameUDPPort))
+		if err != nil {
+			gameUDPErr = fmt.Errorf("resolve udp addr: %w", err)
+			return
+		}
+		conn, err := net.DialUDP("udp", nil, addr)
+		if err != nil {
+			gameUDPErr = fmt.Errorf("dial udp: %w", err)
+			return
+		}
+
+		g := &gameUDPConn{conn: conn, pending: make(map[uint32]*gameUDPPending)}
+		go g.readLoop()
+		sharedGameUDP = g
+	})
+	return sharedGameUDP, gameUDPErr
+}
+
+// readLoop demultiplexes every incoming fragment to its request_id's
+// gameUDPPending, for as long as the process runs; there's one of these per
+// socket, not per request.
+func (g *gameUDPConn) readLoop() {
+	buf := make([]byte, 65535)
+	for {
+		n, err := g.conn.Read(buf)
+		if err != nil {
+			return
+		}
+		g.handleDatagram(buf[:n])
+	}
+}
+
+func (g *gameUDPConn) handleDatagram(data []byte) {
+	if len(data) < gameUDPHeaderSize {
+		return
+	}
+	requestID := binary.BigEndian.Uint32(data[0:4])
+	seq := binary.BigEndian.Uint16(data[4:6])
+	total := binary.BigEndian.Uint16(data[6:8])
+	body := data[gameUDPHeaderSize:]
+
+	g.mu.Lock()
+	p, ok := g.pending[requestID]
+	g.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if p.addFragment(seq, total, body) {
+		select {
+		case p.done <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (g *gameUDPConn) writeFragment(requestID uint32, seq, total uint16, flags byte, body []byte) error {
+	datagram := make([]byte, gameUDPHeaderSize+len(body))
+	binary.BigEndian.PutUint32(datagram[0:4], requestID)
+	binary.BigEndian.PutUint16(datagram[4:6], seq)
+	binary.BigEndian.PutUint16(datagram[6:8], total)
+	datagram[8] = flags
+	copy(datagram[gameUDPHeaderSize:], body)
+
+	_, err := g.conn.Write(datagram)
+	return err
+}
+
+// sendFragments splits payload into maxGameUDPPayload-sized chunks and
+// writes each as its own datagram, including the zero-length case (an
+// empty-bodied command still needs one fragment so the reply can echo it).
+func (g *gameUDPConn) sendFragments(requestID uint32, payload []byte) (uint16, error) {
+	total := uint16((len(payload) + maxGameUDPPayload - 1) / maxGameUDPPayload)
+	if total == 0 {
+		total = 1
+	}
+
+	for seq := uint16(0); seq < total; seq++ {
+		start := int(seq) * maxGameUDPPayload
+		end := start + maxGameUDPPayload
+		if end > len(payload) {
+			end = len(payload)
+		}
+		if err := g.writeFragment(requestID, seq, total, 0, payload[start:end]); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// sendNAK asks the game autoload to resend specific response fragments it
+// apparently dropped, packing the missing seqs as a body of uint16s.
+func (g *gameUDPConn) sendNAK(requestID uint32, missingSeqs []uint16) error {
+	body := make([]byte, len(missingSeqs)*2)
+	for i, seq := range missingSeqs {
+		binary.BigEndian.PutUint16(body[i*2:], seq)
+	}
+	return g.writeFragment(requestID, 0, 0, udpFlagNAK, body)
+}
+
+// send writes payload as one or more fragments under a fresh request_id,
+// then waits for the game autoload's reply fragments to arrive in full:
+// up to gameUDPMaxRetries waits of gameUDPFragmentTimeout each, NAKing any
+// fragments still missing between waits (so at most gameUDPMaxRetries-1
+// NAKs). The request fragments themselves are sent only once.
+func (g *gameUDPConn) send(ctx context.Context, payload []byte) ([]byte, error) {
+	requestID := g.nextID.Add(1)
+
+	p := newGameUDPPending()
+	g.mu.Lock()
+	g.pending[requestID] = p
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		delete(g.pending, requestID)
+		g.mu.Unlock()
+	}()
+
+	if _, err := g.sendFragments(requestID, payload); err != nil {
+		return nil, fmt.Errorf("write udp: %w", err)
+	}
+
+	for attempt := 1; attempt <= gameUDPMaxRetries; attempt++ {
+		select {
+		case <-p.done:
+		case <-time.After(gameUDPFragmentTimeout):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		gaps, complete := p.missing()
+		if complete {
+			return p.assemble(), nil
+		}
+
+		if attempt == gameUDPMaxRetries {
+			break
+		}
+		if len(gaps) == 0 {
+			// No fragment has arrived at all yet (total still unknown), so
+			// there's nothing to NAK - just keep waiting out the timeout.
+			continue
+		}
+		if err := g.sendNAK(requestID, gaps); err != nil {
+			return nil, fmt.Errorf("write udp nak: %w", err)
+		}
+	}
+
+	return nil, fmt.Errorf("udp request timed out waiting for game reply (request_id=%d)", requestID)
+}
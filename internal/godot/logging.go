@@ -0,0 +1,143 @@
+package godot
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a logged event, ordered low to high so Client's
+// configured level can filter events below it.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Logger receives structured events for outbound requests, matched
+// responses, timeouts, reconnections, and decode failures. Fields vary by
+// event but commonly include "id", "method", "latency_ms", and "error".
+type Logger interface {
+	Log(level Level, event string, fields map[string]interface{})
+}
+
+// noopLogger discards everything; it's the default so existing tests and
+// callers that never call SetLogger see no behavior change.
+type noopLogger struct{}
+
+func (noopLogger) Log(Level, string, map[string]interface{}) {}
+
+// JSONLogger writes one JSON object per line to w, suitable for piping into
+// MCP host tooling or a log aggregator.
+type JSONLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLogger creates a Logger that writes newline-delimited JSON to w.
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{w: w}
+}
+
+func (j *JSONLogger) Log(level Level, event string, fields map[string]interface{}) {
+	entry := make(map[string]interface{}, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["level"] = level.String()
+	entry["event"] = event
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.w.Write(data)
+}
+
+// SetLogger installs the Logger events are sent to. Pass nil to restore the
+// default no-op logger.
+func (c *Client) SetLogger(l Logger) {
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	if l == nil {
+		l = noopLogger{}
+	}
+	c.logger = l
+}
+
+// SetLevel sets the minimum severity that reaches the logger.
+func (c *Client) SetLevel(level Level) {
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	c.logLevel = level
+}
+
+// SetFilter installs a predicate consulted (in place of the level check)
+// before an event reaches the logger, e.g. to trace a single noisy method at
+// debug while keeping everything else at warn-and-above:
+//
+//	client.SetLevel(godot.LevelWarn)
+//	client.SetFilter(func(level godot.Level, method string, id int64) bool {
+//	    return level >= godot.LevelWarn || method == "get_output"
+//	})
+//
+// Pass nil to remove the filter (level alone then governs what's logged).
+func (c *Client) SetFilter(f func(level Level, method string, id int64) bool) {
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	c.logFilter = f
+}
+
+// logEvent delivers an event to the configured logger if it passes the
+// configured filter or, when no filter is set, the configured level.
+func (c *Client) logEvent(level Level, method string, id int64, event string, fields map[string]interface{}) {
+	c.logMu.RLock()
+	logger := c.logger
+	minLevel := c.logLevel
+	filter := c.logFilter
+	c.logMu.RUnlock()
+
+	if logger == nil {
+		return
+	}
+	allowed := level >= minLevel
+	if filter != nil {
+		allowed = filter(level, method, id)
+	}
+	if !allowed {
+		return
+	}
+
+	if fields == nil {
+		fields = make(map[string]interface{}, 2)
+	}
+	fields["method"] = method
+	if id != 0 {
+		fields["id"] = id
+	}
+	logger.Log(level, event, fields)
+}
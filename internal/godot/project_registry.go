@@ -0,0 +1,128 @@
+package godot
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProjectRegistry holds every named *Client an MCP server fronts, so one
+// server process can sit in front of a whole workspace of Godot projects
+// instead of assuming the single cwd-derived socket resolveSocketPath would
+// find. The default project (derived from cwd, or GODOT_PEEK_SOCKET) is
+// always present and is used when a tool call omits "project".
+type ProjectRegistry struct {
+	mu          sync.RWMutex
+	defaultName string
+	clients     map[string]*Client
+}
+
+// NewProjectRegistry creates a registry whose default project is named
+// defaultName and backed by defaultClient. Additional projects are wired up
+// with Add.
+func NewProjectRegistry(defaultName string, defaultClient *Client) *ProjectRegistry {
+	return &ProjectRegistry{
+		defaultName: defaultName,
+		clients:     map[string]*Client{defaultName: defaultClient},
+	}
+}
+
+// Add registers an additional named project, replacing any existing client
+// already registered under name.
+func (r *ProjectRegistry) Add(name string, client *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients[name] = client
+}
+
+// Get returns the client registered under name, if any.
+func (r *ProjectRegistry) Get(name string) (*Client, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	client, ok := r.clients[name]
+	return client, ok
+}
+
+// Default returns the registry's default project client.
+func (r *ProjectRegistry) Default() *Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clients[r.defaultName]
+}
+
+// Clients returns every registered client, for callers (like startup
+// supervision) that need to act on all of them regardless of name.
+func (r *ProjectRegistry) Clients() []*Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clients := make([]*Client, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// ProjectStatus reports one registered project's name, socket, and
+// connection state, for the godot_list_projects tool.
+type ProjectStatus struct {
+	Name       string `json:"name"`
+	SocketPath string `json:"socket_path"`
+	Connected  bool   `json:"connected"`
+	Default    bool   `json:"default"`
+}
+
+// List returns every registered project's status, sorted by name.
+func (r *ProjectRegistry) List() []ProjectStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := make([]ProjectStatus, 0, len(names))
+	for _, name := range names {
+		client := r.clients[name]
+		statuses = append(statuses, ProjectStatus{
+			Name:       name,
+			SocketPath: client.socketPath,
+			Connected:  client.IsConnected(),
+			Default:    name == r.defaultName,
+		})
+	}
+	return statuses
+}
+
+// DiscoverSockets scans dir for "godot-peek-<name>.sock" files left behind by
+// other running editor instances, returning a project name to socket path
+// map for any that aren't already registered (so re-scanning is safe).
+func (r *ProjectRegistry) DiscoverSockets(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[string]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "godot-peek-") || !strings.HasSuffix(name, ".sock") {
+			continue
+		}
+		projectName := strings.TrimSuffix(strings.TrimPrefix(name, "godot-peek-"), ".sock")
+		if projectName == "" {
+			continue
+		}
+		if _, exists := r.clients[projectName]; exists {
+			continue
+		}
+		found[projectName] = filepath.Join(dir, name)
+	}
+	return found, nil
+}
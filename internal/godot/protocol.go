@@ -12,18 +12,33 @@ func nextID() int64 {
 	return requestID.Add(1)
 }
 
-// Request represents a JSON-RPC style request to Godot
+// jsonRPCVersion is the protocol marker required on every JSON-RPC 2.0 frame.
+const jsonRPCVersion = "2.0"
+
+// Request represents a JSON-RPC 2.0 request to Godot. A Request with no ID
+// is a notification: Godot processes it but sends no Response.
 type Request struct {
-	ID     int64       `json:"id"`
-	Method string      `json:"method"`
-	Params interface{} `json:"params,omitempty"`
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// newRequest builds a Request stamped with the jsonrpc version field.
+func newRequest(id int64, method string, params interface{}) Request {
+	return Request{JSONRPC: jsonRPCVersion, ID: id, Method: method, Params: params}
 }
 
-// Response represents a JSON-RPC style response from Godot
+// Batch is a group of requests marshaled together as a single JSON array
+// frame, per the JSON-RPC 2.0 batch convention.
+type Batch []Request
+
+// Response represents a JSON-RPC 2.0 response from Godot
 type Response struct {
-	ID     int64            `json:"id"`
-	Result *json.RawMessage `json:"result,omitempty"`
-	Error  *ResponseError   `json:"error,omitempty"`
+	JSONRPC string           `json:"jsonrpc,omitempty"`
+	ID      int64            `json:"id"`
+	Result  *json.RawMessage `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
 }
 
 // ResponseError represents an error in the response
@@ -32,6 +47,17 @@ type ResponseError struct {
 	Message string `json:"message"`
 }
 
+// Standard JSON-RPC 2.0 error codes (spec section 5.1). Codes from -32099
+// to -32000 are reserved for this implementation's own errors, such as
+// errDisconnected (-32000) in client.go.
+const (
+	ErrCodeParseError     = -32700
+	ErrCodeInvalidRequest = -32600
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeInternalError  = -32603
+)
+
 // Notification represents an async message from Godot (no ID)
 type Notification struct {
 	Method string          `json:"method"`
@@ -75,6 +101,20 @@ type GetLocalsParams struct {
 	FrameIndex int `json:"frame_index"`
 }
 
+// GetDebugErrorsParams controls GetDebugErrors. Simplified is a client-side
+// option only - it is never sent to Godot - asking the result's Errors text
+// to be run through stack.SimplifyErrors before it's returned.
+type GetDebugErrorsParams struct {
+	Simplified bool
+}
+
+// GetStackTraceParams controls GetStackTrace. Simplified is a client-side
+// option only - it is never sent to Godot - asking the result's StackTrace
+// text to be run through stack.SimplifyStackTrace before it's returned.
+type GetStackTraceParams struct {
+	Simplified bool
+}
+
 // OutputResult from get_output
 type OutputResult struct {
 	Output      string `json:"output"`
@@ -165,3 +205,135 @@ type MonitorsResult struct {
 	Monitors []MonitorGroup `json:"monitors"`
 	Count    int            `json:"count"`
 }
+
+// StartScreenshotStreamParams for start_screenshot_stream method
+type StartScreenshotStreamParams struct {
+	Target string `json:"target"` // "game" or "editor"
+	FPS    int    `json:"fps"`
+}
+
+// ScreenshotFrameNotification is the params for "screenshot_frame"
+// notifications sent once per captured frame while a stream is running.
+type ScreenshotFrameNotification struct {
+	Target    string  `json:"target"`
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	Timestamp float64 `json:"timestamp"`
+	PNGBase64 string  `json:"png_base64"`
+}
+
+// SetBreakpointParams for set_breakpoint method. Condition, HitCondition,
+// and LogMessage have no Godot-native equivalent; they're tracked in
+// Client.Breakpoints and evaluated here when a pause is observed.
+type SetBreakpointParams struct {
+	Path         string `json:"path"`
+	Line         int    `json:"line"`
+	Enabled      bool   `json:"enabled"`
+	Condition    string `json:"condition,omitempty"`
+	HitCondition string `json:"hit_condition,omitempty"`
+	LogMessage   string `json:"log_message,omitempty"`
+}
+
+// DebugStepParams for debug_step method
+type DebugStepParams struct {
+	Mode string `json:"mode"` // "into", "over", or "out"
+}
+
+// DebuggerStateResult from get_debugger_state. ConditionalBreakpoints is
+// populated client-side from Client.Breakpoints, not sent by Godot.
+type DebuggerStateResult struct {
+	Paused                 bool             `json:"paused"`
+	Active                 bool             `json:"active"`
+	Debuggable             bool             `json:"debuggable"`
+	IsPlaying              bool             `json:"is_playing"`
+	ConditionalBreakpoints []BreakpointInfo `json:"conditional_breakpoints,omitempty"`
+}
+
+// EvaluateResult from the game autoload's "evaluate" UDP command
+type EvaluateResult struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+// InputResult from the game autoload's "input" UDP command
+type InputResult struct {
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+// InputSequenceStep is a single event within an input_sequence UDP command.
+// DelayMs is waited before the event is dispatched; HoldMs, if set, causes
+// the game autoload to dispatch a matching "pressed: false" release after
+// that many milliseconds.
+type InputSequenceStep struct {
+	Type           string        `json:"type"`
+	Action         string        `json:"action,omitempty"`
+	Keycode        string        `json:"keycode,omitempty"`
+	Button         string        `json:"button,omitempty"`
+	Pressed        bool          `json:"pressed"`
+	Strength       float64       `json:"strength,omitempty"`
+	Position       []interface{} `json:"position,omitempty"`
+	GlobalPosition []interface{} `json:"global_position,omitempty"`
+	Relative       []interface{} `json:"relative,omitempty"`
+	Echo           bool          `json:"echo,omitempty"`
+	ShiftPressed   bool          `json:"shift_pressed,omitempty"`
+	CtrlPressed    bool          `json:"ctrl_pressed,omitempty"`
+	AltPressed     bool          `json:"alt_pressed,omitempty"`
+	MetaPressed    bool          `json:"meta_pressed,omitempty"`
+	Index          float64       `json:"index,omitempty"`
+	TapCount       float64       `json:"tap_count,omitempty"`
+	Factor         float64       `json:"factor,omitempty"`
+	Delta          []interface{} `json:"delta,omitempty"`
+	Device         float64       `json:"device,omitempty"`
+	Axis           float64       `json:"axis,omitempty"`
+	AxisValue      float64       `json:"axis_value,omitempty"`
+	DelayMs        int           `json:"delay_ms,omitempty"`
+	HoldMs         int           `json:"hold_ms,omitempty"`
+}
+
+// InputSequenceStepResult reports the delivery outcome of a single step, so
+// a partial failure mid-sequence is diagnosable.
+type InputSequenceStepResult struct {
+	Index     int    `json:"index"`
+	Type      string `json:"type"`
+	Delivered bool   `json:"delivered"`
+	Error     string `json:"error,omitempty"`
+}
+
+// StartInputRecordingParams for the game autoload's "start_input_recording"
+// UDP command. Filters keep long recordings from exploding: EventTypes
+// restricts which InputEvent kinds are captured, NodePath restricts capture
+// to events observed by that node's _unhandled_input, and
+// MaxDurationSeconds auto-stops the recording.
+type StartInputRecordingParams struct {
+	EventTypes         []string `json:"event_types,omitempty"`
+	NodePath           string   `json:"node_path,omitempty"`
+	MaxDurationSeconds float64  `json:"max_duration_seconds,omitempty"`
+}
+
+// StartInputRecordingResult from the game autoload's "start_input_recording"
+// UDP command
+type StartInputRecordingResult struct {
+	Recording bool   `json:"recording"`
+	Error     string `json:"error,omitempty"`
+}
+
+// StopInputRecordingResult from the game autoload's "stop_input_recording"
+// UDP command. Events is shaped identically to send_input_sequence's steps
+// so a recording can be replayed (or mutated and replayed) directly.
+type StopInputRecordingResult struct {
+	Events     []InputSequenceStep `json:"events"`
+	Count      int                 `json:"count"`
+	DurationMs int                 `json:"duration_ms"`
+	SavedPath  string              `json:"saved_path,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// InputSequenceResult from the game autoload's "input_sequence" UDP command
+type InputSequenceResult struct {
+	Delivered int                       `json:"delivered"`
+	Total     int                       `json:"total"`
+	Steps     []InputSequenceStepResult `json:"steps"`
+	Error     string                    `json:"error,omitempty"`
+}
@@ -0,0 +1,183 @@
+package godot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// RecordedEvent is one line of a --record capture: either the request a
+// Client sent to Godot or the response it got back, with enough context
+// (method, params, correlation ID) for --replay to answer later without a
+// real editor connection.
+type RecordedEvent struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Direction string           `json:"direction"` // "request" or "response"
+	ID        int64            `json:"id"`
+	Method    string           `json:"method,omitempty"`
+	Params    json.RawMessage  `json:"params,omitempty"`
+	Result    *json.RawMessage `json:"result,omitempty"`
+	Error     *ResponseError   `json:"error,omitempty"`
+}
+
+// Recorder appends RecordedEvents to a --record capture as newline-delimited
+// JSON, one line per request or response frame a Client exchanges with
+// Godot. Attach one to a Client via SetRecorder.
+type Recorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRecorder wraps w (typically an opened --record file) as a Recorder.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// record appends one frame, stamping it with the current time. Marshal/write
+// errors are swallowed - a broken capture shouldn't take down a live session.
+func (r *Recorder) record(ev RecordedEvent) {
+	ev.Timestamp = time.Now()
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.w.Write(data)
+	r.w.Write([]byte("\n"))
+}
+
+// LoadReplayLog reads a --record capture back into memory for --replay.
+func LoadReplayLog(r io.Reader) ([]RecordedEvent, error) {
+	var events []RecordedEvent
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var ev RecordedEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, fmt.Errorf("parse recorded event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// replayState answers sendRequest calls on a NewReplayClient from a loaded
+// --record capture instead of a live socket: it pairs each recorded response
+// with the params of the request that produced it, then replays by matching
+// method + params hash first and falling back to sequential order.
+type replayState struct {
+	mu      sync.Mutex
+	byKey   map[string][]RecordedEvent
+	seq     []RecordedEvent
+	seqNext int
+}
+
+func newReplayState(events []RecordedEvent) *replayState {
+	reqParamsByID := make(map[int64]json.RawMessage)
+	st := &replayState{byKey: make(map[string][]RecordedEvent)}
+
+	for _, ev := range events {
+		switch ev.Direction {
+		case "request":
+			reqParamsByID[ev.ID] = ev.Params
+		case "response":
+			st.seq = append(st.seq, ev)
+			key := replayKey(ev.Method, reqParamsByID[ev.ID])
+			st.byKey[key] = append(st.byKey[key], ev)
+		}
+	}
+	return st
+}
+
+// replayKey hashes params (rather than comparing raw JSON) so key order or
+// whitespace differences between a live call and its recording don't matter.
+func replayKey(method string, params json.RawMessage) string {
+	canonical := []byte(params)
+	var v interface{}
+	if err := json.Unmarshal(params, &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			canonical = b
+		}
+	}
+	sum := sha256.Sum256(canonical)
+	return method + ":" + hex.EncodeToString(sum[:])
+}
+
+// next returns the recorded response for (method, params), preferring an
+// exact hash match, then the first response for the same method at or after
+// a sequential cursor in recording order, then the response at the cursor
+// whatever its method - so a replay never stalls just because one call
+// drifted slightly from what was captured. The hash matches and the cursor
+// are tracked separately: a response taken by hash can still be returned
+// again by the sequential fallback, and responses the cursor skips over are
+// not returned by it again.
+func (s *replayState) next(method string, params json.RawMessage) (RecordedEvent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := replayKey(method, params)
+	if queue := s.byKey[key]; len(queue) > 0 {
+		ev := queue[0]
+		s.byKey[key] = queue[1:]
+		return ev, true
+	}
+
+	for i := s.seqNext; i < len(s.seq); i++ {
+		if s.seq[i].Method == method {
+			s.seqNext = i + 1
+			return s.seq[i], true
+		}
+	}
+
+	if s.seqNext < len(s.seq) {
+		ev := s.seq[s.seqNext]
+		s.seqNext++
+		return ev, true
+	}
+
+	return RecordedEvent{}, false
+}
+
+// NewReplayClient builds a Client that never touches a real socket: every
+// sendRequest is answered from events (loaded via LoadReplayLog) instead of
+// an actual Godot editor, for deterministic bug reproducers, tools.Register
+// fixtures, and an offline demo mode for contributors without a Godot
+// checkout.
+func NewReplayClient(socketPath string, events []RecordedEvent) *Client {
+	c := NewClient(socketPath)
+	c.connected = true
+	c.replay = newReplayState(events)
+	return c
+}
+
+// sendRequestReplay is sendRequest's replay-mode counterpart: it never
+// blocks and never fails for connectivity reasons, only for an exhausted or
+// never-captured recording.
+func (c *Client) sendRequestReplay(method string, params interface{}) (*Response, error) {
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	ev, ok := c.replay.next(method, paramsJSON)
+	if !ok {
+		return nil, fmt.Errorf("replay: no recorded response for %q (recording exhausted or method never captured)", method)
+	}
+	return &Response{JSONRPC: jsonRPCVersion, ID: nextID(), Result: ev.Result, Error: ev.Error}, nil
+}
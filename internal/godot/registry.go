@@ -0,0 +1,83 @@
+package godot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// MethodPlugin describes a Godot editor command that isn't one of the
+// built-in typed methods on Client. Registering one lets third parties add
+// new commands (e.g. "reload_scripts", "take_screenshot") without forking
+// this package: CallMethod uses the registry to know how to unmarshal the
+// result, and tools.Register can expose it generically over MCP.
+type MethodPlugin interface {
+	// Name is the JSON-RPC method name sent to Godot, e.g. "reload_scripts".
+	Name() string
+	// ParamsPrototype returns a zero value of the params type, used by
+	// callers that need to know the shape expected for this method.
+	ParamsPrototype() interface{}
+	// NewResult returns a fresh pointer to unmarshal this method's result
+	// into, e.g. &ReloadScriptsResult{}.
+	NewResult() interface{}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]MethodPlugin)
+)
+
+// RegisterMethod adds a plugin to the package-level method registry. Typically
+// called from a downstream package's init(). Registering the same name twice
+// replaces the previous plugin.
+func RegisterMethod(p MethodPlugin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[p.Name()] = p
+}
+
+// LookupMethod returns the registered plugin for a method name, if any.
+func LookupMethod(name string) (MethodPlugin, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[name]
+	return p, ok
+}
+
+// RegisteredMethods returns the names of all currently registered plugin methods.
+func RegisteredMethods() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
+
+// CallMethod sends a request for a registry-backed method and unmarshals the
+// result using the plugin's NewResult(). This is how third-party methods
+// become callable without Client growing a bespoke typed wrapper for each one.
+func (c *Client) CallMethod(ctx context.Context, name string, params interface{}) (interface{}, error) {
+	plugin, ok := LookupMethod(name)
+	if !ok {
+		return nil, fmt.Errorf("no registered method plugin for %q", name)
+	}
+
+	resp, err := c.sendRequest(ctx, name, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("godot error: %s", resp.Error.Message)
+	}
+
+	result := plugin.NewResult()
+	if resp.Result != nil {
+		if err := json.Unmarshal(*resp.Result, result); err != nil {
+			return nil, fmt.Errorf("unmarshal result: %w", err)
+		}
+	}
+	return result, nil
+}
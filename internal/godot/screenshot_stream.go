@@ -0,0 +1,121 @@
+package godot
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ScreenshotFrame is one decoded frame delivered by StreamScreenshots.
+type ScreenshotFrame struct {
+	Target    string
+	Width     float64
+	Height    float64
+	Timestamp time.Time
+	PNG       []byte
+}
+
+// maxFrameBuffer is the ring buffer size for RecentFrames - far smaller than
+// MaxOutputBuffer since frames are PNGs, not log lines.
+const maxFrameBuffer = 30
+
+// addRecentFrame appends f to the ring buffer RecentFrames reads, trimming
+// the oldest entry once maxFrameBuffer is exceeded (mirrors addOutput).
+func (c *Client) addRecentFrame(f ScreenshotFrame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.recentFrames = append(c.recentFrames, f)
+	if len(c.recentFrames) > maxFrameBuffer {
+		c.recentFrames = c.recentFrames[len(c.recentFrames)-maxFrameBuffer:]
+	}
+}
+
+// RecentFrames returns up to n of the most recently captured stream frames,
+// oldest first. n<=0 or n greater than the buffer's size returns everything
+// available.
+func (c *Client) RecentFrames(n int) []ScreenshotFrame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n <= 0 || n > len(c.recentFrames) {
+		n = len(c.recentFrames)
+	}
+	start := len(c.recentFrames) - n
+	out := make([]ScreenshotFrame, n)
+	copy(out, c.recentFrames[start:])
+	return out
+}
+
+// StreamScreenshots negotiates a start_screenshot_stream request with Godot,
+// then decodes the "screenshot_frame" notifications it sends back (via the
+// same dispatcher SubscribeMethod uses) into a channel of frames. Every
+// frame delivered is also appended to the ring buffer RecentFrames reads.
+// The stream stops - sending stop_screenshot_stream and closing the
+// returned channel - once ctx is done.
+func (c *Client) StreamScreenshots(ctx context.Context, target string, fps int) (<-chan ScreenshotFrame, error) {
+	resp, err := c.sendRequest(ctx, "start_screenshot_stream", StartScreenshotStreamParams{Target: target, FPS: fps})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("godot error: %s", resp.Error.Message)
+	}
+
+	subID, notes := c.SubscribeMethod("screenshot_frame", maxFrameBuffer)
+	out := make(chan ScreenshotFrame, maxFrameBuffer)
+
+	go func() {
+		defer close(out)
+		defer c.Unsubscribe(subID)
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), heartbeatTimeout)
+			defer cancel()
+			c.sendRequest(stopCtx, "stop_screenshot_stream", nil)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case note, ok := <-notes:
+				if !ok {
+					return
+				}
+				frame, err := decodeScreenshotFrame(note.Params)
+				if err != nil {
+					continue
+				}
+				c.addRecentFrame(frame)
+				select {
+				case out <- frame:
+				default:
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func decodeScreenshotFrame(params json.RawMessage) (ScreenshotFrame, error) {
+	var note ScreenshotFrameNotification
+	if err := json.Unmarshal(params, &note); err != nil {
+		return ScreenshotFrame{}, fmt.Errorf("unmarshal screenshot_frame: %w", err)
+	}
+
+	png, err := base64.StdEncoding.DecodeString(note.PNGBase64)
+	if err != nil {
+		return ScreenshotFrame{}, fmt.Errorf("decode screenshot_frame png: %w", err)
+	}
+
+	return ScreenshotFrame{
+		Target:    note.Target,
+		Width:     note.Width,
+		Height:    note.Height,
+		Timestamp: time.UnixMilli(int64(note.Timestamp * 1000)),
+		PNG:       png,
+	}, nil
+}
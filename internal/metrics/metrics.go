@@ -0,0 +1,156 @@
+// Package metrics exposes a Prometheus text-exposition /metrics endpoint
+// backed by periodic godot.Client.GetMonitors polls, so FPS, memory, and
+// other engine monitors are scrapeable by standard tooling.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+)
+
+// DefaultPollInterval is how often Collector.Run polls GetMonitors when the
+// caller doesn't specify one.
+const DefaultPollInterval = 5 * time.Second
+
+// Collector periodically polls a godot.Client for monitor data and renders
+// it as Prometheus gauges.
+type Collector struct {
+	client   *godot.Client
+	interval time.Duration
+
+	mu             sync.RWMutex
+	groups         []godot.MonitorGroup
+	connected      bool
+	gameRunning    bool
+	debuggerPaused bool
+}
+
+// NewCollector creates a Collector that polls client at the given interval
+// (DefaultPollInterval if zero or negative).
+func NewCollector(client *godot.Client, interval time.Duration) *Collector {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Collector{client: client, interval: interval}
+}
+
+// Run polls until ctx is done. A scrape while the editor is disconnected
+// reads zeroed gauges rather than hanging, since Run skips calling Godot on
+// every tick where IsConnected() is false.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+func (c *Collector) poll(ctx context.Context) {
+	if !c.client.IsConnected() {
+		c.mu.Lock()
+		c.connected = false
+		c.gameRunning = false
+		c.debuggerPaused = false
+		c.groups = nil
+		c.mu.Unlock()
+		return
+	}
+
+	monitors, monitorsErr := c.client.GetMonitors(ctx)
+	state, stateErr := c.client.GetDebuggerState(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.connected = true
+	if monitorsErr == nil {
+		c.groups = monitors.Monitors
+	}
+	if stateErr == nil {
+		c.gameRunning = state.Active
+		c.debuggerPaused = state.Paused
+	}
+}
+
+// nonAlnumRe matches runs of characters that aren't valid in a Prometheus
+// metric name segment.
+var nonAlnumRe = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func sanitizeMetricPart(s string) string {
+	s = nonAlnumRe.ReplaceAllString(strings.ToLower(s), "_")
+	return strings.Trim(s, "_")
+}
+
+func boolGauge(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// WriteMetrics renders the current collector state as Prometheus text
+// exposition format.
+func (c *Collector) WriteMetrics(w io.Writer) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fmt.Fprintf(w, "# TYPE godot_peek_connected gauge\ngodot_peek_connected %d\n", boolGauge(c.connected))
+	fmt.Fprintf(w, "# TYPE godot_peek_game_running gauge\ngodot_peek_game_running %d\n", boolGauge(c.gameRunning))
+	fmt.Fprintf(w, "# TYPE godot_peek_debugger_paused gauge\ngodot_peek_debugger_paused %d\n", boolGauge(c.debuggerPaused))
+
+	for _, group := range c.groups {
+		groupName := sanitizeMetricPart(group.Group)
+		for _, metric := range group.Metrics {
+			value, err := strconv.ParseFloat(strings.TrimSpace(metric.Value), 64)
+			if err != nil {
+				continue // non-numeric monitor value, nothing to scrape
+			}
+			name := fmt.Sprintf("godot_%s_%s", groupName, sanitizeMetricPart(metric.Name))
+			fmt.Fprintf(w, "# TYPE %s gauge\n%s{group=%q} %v\n", name, name, group.Group, value)
+		}
+	}
+}
+
+// Handler returns an http.Handler serving /metrics in Prometheus text format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		c.WriteMetrics(w)
+	})
+}
+
+// Serve starts an HTTP server on addr exposing /metrics, and polls client in
+// the background until ctx is done.
+func Serve(ctx context.Context, client *godot.Client, addr string, interval time.Duration) error {
+	collector := NewCollector(client, interval)
+	go collector.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector.Handler())
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	return nil
+}
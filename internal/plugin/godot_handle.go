@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+)
+
+// GodotHandle is the restricted surface of godot.Client plugins are allowed
+// to call back into. It deliberately doesn't expose the full Client - e.g.
+// no debugger control or scene launching - so a misbehaving plugin can
+// query state but can't drive the editor.
+type GodotHandle struct {
+	client *godot.Client
+}
+
+// NewGodotHandle wraps client for plugin use.
+func NewGodotHandle(client *godot.Client) *GodotHandle {
+	return &GodotHandle{client: client}
+}
+
+// EvalExpression evaluates a GDScript expression in the running game.
+func (h *GodotHandle) EvalExpression(ctx context.Context, expression string) (string, error) {
+	result, err := h.client.EvaluateExpression(ctx, expression)
+	if err != nil {
+		return "", err
+	}
+	return result.Value, nil
+}
+
+// GetNodeTree returns the remote scene tree as text.
+func (h *GodotHandle) GetNodeTree(ctx context.Context) (string, error) {
+	result, err := h.client.GetRemoteSceneTree(ctx)
+	if err != nil {
+		return "", err
+	}
+	return result.Tree, nil
+}
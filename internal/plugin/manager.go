@@ -0,0 +1,381 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// initialRestartBackoff is the delay before the first restart attempt
+	// after a plugin crashes; it doubles after each failed restart attempt
+	// (launch or manifest fetch) up to maxRestartBackoff, and resets to this
+	// value once a restart succeeds.
+	initialRestartBackoff = 1 * time.Second
+	maxRestartBackoff     = 30 * time.Second
+
+	// rpcTimeout bounds a single request/response round-trip with a plugin.
+	rpcTimeout = 10 * time.Second
+)
+
+// plugin is one running plugin subprocess and its RPC connection.
+type plugin struct {
+	path  string
+	godot *GodotHandle
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	nextID  atomic.Int64
+	pending map[int64]chan envelope
+	pendMu  sync.Mutex
+
+	manifestMu sync.RWMutex
+	manifests  []ToolManifest
+}
+
+// Manager discovers plugin executables, launches and supervises them, and
+// routes MCP tool calls to whichever plugin declared the tool.
+type Manager struct {
+	godot *GodotHandle
+
+	mu      sync.RWMutex
+	plugins []*plugin
+	byTool  map[string]*plugin
+}
+
+// NewManager creates a Manager whose plugins can call back into Godot only
+// through godotHandle's restricted surface.
+func NewManager(godotHandle *GodotHandle) *Manager {
+	return &Manager{
+		godot:  godotHandle,
+		byTool: make(map[string]*plugin),
+	}
+}
+
+// Discover scans dir for executable files and launches each as a plugin.
+// A plugin that fails to start or hand back a manifest is logged and
+// skipped rather than aborting discovery of the rest.
+func (m *Manager) Discover(ctx context.Context, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read plugins dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			continue // not executable
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		p := &plugin{path: path, godot: m.godot, pending: make(map[int64]chan envelope)}
+		if err := m.start(ctx, p); err != nil {
+			log.Printf("[plugin] %s: failed to start: %v", path, err)
+			continue
+		}
+
+		m.mu.Lock()
+		m.plugins = append(m.plugins, p)
+		for _, t := range p.getManifests() {
+			m.byTool[t.Name] = p
+		}
+		m.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Tools returns the manifests of every tool registered by a live plugin.
+func (m *Manager) Tools() []ToolManifest {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var tools []ToolManifest
+	for _, p := range m.plugins {
+		tools = append(tools, p.getManifests()...)
+	}
+	return tools
+}
+
+// Call proxies an MCP tool call to the plugin that owns it.
+func (m *Manager) Call(ctx context.Context, name string, args json.RawMessage) (json.RawMessage, error) {
+	m.mu.RLock()
+	p, ok := m.byTool[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no plugin registered for tool %q", name)
+	}
+
+	params, err := json.Marshal(callParams{Name: name, Args: args})
+	if err != nil {
+		return nil, fmt.Errorf("marshal call params: %w", err)
+	}
+
+	resp, err := p.call(ctx, "call", params)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// start launches p's subprocess, fetches its manifest, and spawns the
+// supervisor goroutine that restarts it on an unexpected exit.
+func (m *Manager) start(ctx context.Context, p *plugin) error {
+	if err := p.launch(); err != nil {
+		return err
+	}
+
+	manifest, err := p.fetchManifest(ctx)
+	if err != nil {
+		p.kill()
+		return err
+	}
+	p.setManifests(manifest.Tools)
+
+	go m.supervise(ctx, p)
+	return nil
+}
+
+// supervise waits for p's process to exit and restarts it with exponential
+// backoff, unless ctx has been canceled (shutdown, not a crash).
+func (m *Manager) supervise(ctx context.Context, p *plugin) {
+	backoff := initialRestartBackoff
+
+	for {
+		err := p.wait()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[plugin] %s exited (%v), restarting in %s", p.path, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if err := p.launch(); err != nil {
+			log.Printf("[plugin] %s: restart failed: %v", p.path, err)
+		} else if manifest, err := p.fetchManifest(ctx); err != nil {
+			log.Printf("[plugin] %s: manifest re-fetch failed: %v", p.path, err)
+			p.kill()
+		} else {
+			p.setManifests(manifest.Tools)
+			m.mu.Lock()
+			for _, t := range manifest.Tools {
+				m.byTool[t.Name] = p
+			}
+			m.mu.Unlock()
+			backoff = initialRestartBackoff
+			continue
+		}
+
+		backoff *= 2
+		if backoff > maxRestartBackoff {
+			backoff = maxRestartBackoff
+		}
+	}
+}
+
+func (p *plugin) getManifests() []ToolManifest {
+	p.manifestMu.RLock()
+	defer p.manifestMu.RUnlock()
+	return p.manifests
+}
+
+func (p *plugin) setManifests(tools []ToolManifest) {
+	p.manifestMu.Lock()
+	p.manifests = tools
+	p.manifestMu.Unlock()
+}
+
+// launch starts (or restarts) the plugin's subprocess and its read loop.
+func (p *plugin) launch() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cmd := exec.Command(p.path)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	p.cmd = cmd
+	p.stdin = stdin
+	p.pending = make(map[int64]chan envelope)
+
+	go p.readLoop(stdout)
+	return nil
+}
+
+func (p *plugin) wait() error {
+	p.mu.Lock()
+	cmd := p.cmd
+	p.mu.Unlock()
+	if cmd == nil {
+		return fmt.Errorf("plugin not running")
+	}
+	return cmd.Wait()
+}
+
+func (p *plugin) kill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cmd != nil && p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+	}
+}
+
+// readLoop dispatches frames from the plugin's stdout: a frame with Method
+// set is a request from the plugin (godot.* callback), handled and replied
+// to on its own goroutine; otherwise it's a response delivered to the
+// pending caller.
+func (p *plugin) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg envelope
+		if err := json.Unmarshal(line, &msg); err != nil {
+			log.Printf("[plugin] %s: malformed frame: %v", p.path, err)
+			continue
+		}
+
+		if msg.Method != "" {
+			go p.handleHostRPC(msg)
+			continue
+		}
+
+		p.pendMu.Lock()
+		ch, ok := p.pending[msg.ID]
+		if ok {
+			delete(p.pending, msg.ID)
+		}
+		p.pendMu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+// handleHostRPC serves a callback request from the plugin against the
+// restricted GodotHandle, and writes the response back on stdin.
+func (p *plugin) handleHostRPC(req envelope) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	resp := envelope{ID: req.ID}
+	switch req.Method {
+	case "godot.eval_expression":
+		var params evalExpressionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		value, err := p.godot.EvalExpression(ctx, params.Expression)
+		if err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		resp.Result, _ = json.Marshal(value)
+	case "godot.get_node_tree":
+		tree, err := p.godot.GetNodeTree(ctx)
+		if err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		resp.Result, _ = json.Marshal(tree)
+	default:
+		resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+	}
+
+	p.writeFrame(resp)
+}
+
+// call sends a request to the plugin and waits for its matching response.
+func (p *plugin) call(ctx context.Context, method string, params json.RawMessage) (json.RawMessage, error) {
+	id := p.nextID.Add(1)
+	ch := make(chan envelope, 1)
+
+	p.pendMu.Lock()
+	p.pending[id] = ch
+	p.pendMu.Unlock()
+
+	if err := p.writeFrame(envelope{ID: id, Method: method, Params: params}); err != nil {
+		p.pendMu.Lock()
+		delete(p.pending, id)
+		p.pendMu.Unlock()
+		return nil, err
+	}
+
+	timeout := time.NewTimer(rpcTimeout)
+	defer timeout.Stop()
+
+	select {
+	case resp := <-ch:
+		if resp.Error != "" {
+			return nil, fmt.Errorf("plugin error: %s", resp.Error)
+		}
+		return resp.Result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timeout.C:
+		return nil, fmt.Errorf("plugin %s: %s timed out", p.path, method)
+	}
+}
+
+// fetchManifest asks a freshly launched plugin for its tool list.
+func (p *plugin) fetchManifest(ctx context.Context) (*manifestResult, error) {
+	raw, err := p.call(ctx, "manifest", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result manifestResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest: %w", err)
+	}
+	return &result, nil
+}
+
+func (p *plugin) writeFrame(msg envelope) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal frame: %w", err)
+	}
+	data = append(data, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stdin == nil {
+		return fmt.Errorf("plugin %s: not connected", p.path)
+	}
+	_, err = p.stdin.Write(data)
+	return err
+}
@@ -0,0 +1,43 @@
+// Package plugin implements a lightweight subprocess RPC system for
+// third-party MCP tools. Plugins are executables discovered from a
+// directory; each speaks line-delimited JSON-RPC over its own stdin/stdout,
+// symmetric in both directions: the host calls "manifest"/"call" on the
+// plugin, and the plugin may call back "godot.*" methods against a
+// restricted GodotHandle without reimplementing the socket protocol.
+package plugin
+
+import "encoding/json"
+
+// envelope is both a request and a response frame. A frame with Method set
+// is a request (from either side); one without is a response matched to a
+// pending call by ID.
+type envelope struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ToolManifest describes one MCP tool a plugin exposes.
+type ToolManifest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// manifestResult is the "manifest" RPC's result shape.
+type manifestResult struct {
+	Tools []ToolManifest `json:"tools"`
+}
+
+// callParams is the "call" RPC's params shape.
+type callParams struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args"`
+}
+
+// evalExpressionParams is the "godot.eval_expression" RPC's params shape.
+type evalExpressionParams struct {
+	Expression string `json:"expression"`
+}
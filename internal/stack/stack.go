@@ -0,0 +1,200 @@
+// Package stack collapses Godot debugger stack traces and error logs into
+// a more compact form: repeated frames caused by recursion are folded into
+// a single "xN" entry, and when a raw blob contains several traces (the
+// Debugger Errors tab often logs the same call path once per occurrence),
+// the traces sharing a common prefix are merged into one tree.
+package stack
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Frame is one parsed call-stack entry from Godot's debugger output, which
+// renders each line as "<n>. Function: <fn> - Source: <path>:<line>".
+type Frame struct {
+	Function string
+	Source   string
+	Line     int
+}
+
+// frameLine matches a single Godot stack frame line. Leading/trailing
+// whitespace and the frame's own index number are ignored since frames are
+// re-numbered on render anyway.
+var frameLine = regexp.MustCompile(`^\s*\d+\.\s+Function:\s+(.+?)\s+-\s+Source:\s+(.+):(\d+)\s*$`)
+
+// ParseFrames parses every frame line in raw, in the order Godot printed
+// them (index 0 is the innermost/most-recent frame). Lines that don't match
+// the "<n>. Function: ... - Source: ...:<line>" shape are skipped, so a
+// leading header line ("Game paused on error...") is harmless.
+func ParseFrames(raw string) []Frame {
+	var frames []Frame
+	for _, line := range strings.Split(raw, "\n") {
+		if f, ok := parseFrameLine(line); ok {
+			frames = append(frames, f)
+		}
+	}
+	return frames
+}
+
+func parseFrameLine(line string) (Frame, bool) {
+	m := frameLine.FindStringSubmatch(line)
+	if m == nil {
+		return Frame{}, false
+	}
+	lineNum, err := strconv.Atoi(m[3])
+	if err != nil {
+		return Frame{}, false
+	}
+	return Frame{Function: m[1], Source: m[2], Line: lineNum}, true
+}
+
+// ParseTraces splits raw into one or more stack traces: Godot's Debugger
+// Errors tab separates distinct occurrences with a blank line, each being a
+// run of consecutive frame lines. A blank line or a non-frame line ends the
+// current trace.
+func ParseTraces(raw string) [][]Frame {
+	var traces [][]Frame
+	var current []Frame
+	flush := func() {
+		if len(current) > 0 {
+			traces = append(traces, current)
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(raw, "\n") {
+		f, ok := parseFrameLine(line)
+		if !ok {
+			flush()
+			continue
+		}
+		current = append(current, f)
+	}
+	flush()
+	return traces
+}
+
+// collapsedFrame is one run of identical consecutive frames.
+type collapsedFrame struct {
+	frame Frame
+	count int
+}
+
+// collapseRecursion groups consecutive identical frames - the signature of
+// unbounded recursion - into a single entry carrying its repeat count.
+func collapseRecursion(frames []Frame) []collapsedFrame {
+	var out []collapsedFrame
+	for _, f := range frames {
+		if n := len(out); n > 0 && out[n-1].frame == f {
+			out[n-1].count++
+			continue
+		}
+		out = append(out, collapsedFrame{frame: f, count: 1})
+	}
+	return out
+}
+
+func renderFrame(indent string, f Frame, count int) string {
+	line := fmt.Sprintf("%sFunction: %s - Source: %s:%d", indent, f.Function, f.Source, f.Line)
+	if count > 1 {
+		line += fmt.Sprintf(" (x%d)", count)
+	}
+	return line
+}
+
+// SimplifyStackTrace collapses consecutive identical frames (recursion) in
+// a single stack trace down to one line annotated with a "x<count>" repeat.
+// Input that contains no parseable frames is returned unchanged.
+func SimplifyStackTrace(raw string) string {
+	frames := ParseFrames(raw)
+	if len(frames) == 0 {
+		return raw
+	}
+
+	collapsed := collapseRecursion(frames)
+	lines := make([]string, len(collapsed))
+	for i, cf := range collapsed {
+		lines[i] = fmt.Sprintf("%d. %s", i, renderFrame("", cf.frame, cf.count))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// traceNode is one node of the tree SimplifyErrors renders: frame is this
+// node's call-stack entry, count is how many times traces passed through it
+// (a collapsed recursive run adds its full repeat count), and children are
+// the distinct frames traces went on to after it.
+type traceNode struct {
+	frame    Frame
+	count    int
+	children []*traceNode
+}
+
+// mergeTraces builds a tree from traces. Each trace has its own consecutive
+// repeated frames (recursion) collapsed first, same as SimplifyStackTrace,
+// so a recursive call path contributes one node with a repeat count instead
+// of one node per recursive call. The collapsed trace is then walked from
+// its outermost frame inward (the reverse of Godot's innermost-first
+// ordering) so that traces sharing the same entry point into a recursive or
+// looping function merge into a common root, branching only where they
+// diverge near the error site.
+func mergeTraces(traces [][]Frame) []*traceNode {
+	var roots []*traceNode
+	for _, trace := range traces {
+		roots = insertTrace(roots, reversed(collapseRecursion(trace)))
+	}
+	return roots
+}
+
+func reversed(frames []collapsedFrame) []collapsedFrame {
+	out := make([]collapsedFrame, len(frames))
+	for i, f := range frames {
+		out[len(frames)-1-i] = f
+	}
+	return out
+}
+
+func insertTrace(nodes []*traceNode, frames []collapsedFrame) []*traceNode {
+	if len(frames) == 0 {
+		return nodes
+	}
+	head, rest := frames[0], frames[1:]
+	for _, n := range nodes {
+		if n.frame == head.frame {
+			n.count += head.count
+			n.children = insertTrace(n.children, rest)
+			return nodes
+		}
+	}
+	node := &traceNode{frame: head.frame, count: head.count}
+	node.children = insertTrace(node.children, rest)
+	return append(nodes, node)
+}
+
+func renderTree(nodes []*traceNode, depth int, sb *strings.Builder) {
+	for _, n := range nodes {
+		sb.WriteString(renderFrame(strings.Repeat("  ", depth), n.frame, n.count))
+		sb.WriteString("\n")
+		renderTree(n.children, depth+1, sb)
+	}
+}
+
+// SimplifyErrors merges the stack traces found in raw - the Debugger Errors
+// tab often logs the same call path once per occurrence - into a tree: a
+// shared prefix (in call order, outermost frame first) renders once, and
+// only the frames where traces diverge branch out, each annotated with how
+// many times traces passed through it (consecutive recursive repeats within
+// one trace are collapsed and counted, same as SimplifyStackTrace). Input
+// with no parseable traces is returned unchanged.
+func SimplifyErrors(raw string) string {
+	traces := ParseTraces(raw)
+	if len(traces) == 0 {
+		return raw
+	}
+
+	roots := mergeTraces(traces)
+	var sb strings.Builder
+	renderTree(roots, 0, &sb)
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,79 @@
+package stack
+
+import "testing"
+
+func TestSimplifyStackTrace_CollapsesRecursion(t *testing.T) {
+	raw := `0. Function: factorial - Source: res://scripts/math.gd:10
+1. Function: factorial - Source: res://scripts/math.gd:10
+2. Function: factorial - Source: res://scripts/math.gd:10
+3. Function: factorial - Source: res://scripts/math.gd:10
+4. Function: _ready - Source: res://scripts/main.gd:5`
+
+	want := `0. Function: factorial - Source: res://scripts/math.gd:10 (x4)
+1. Function: _ready - Source: res://scripts/main.gd:5`
+
+	if got := SimplifyStackTrace(raw); got != want {
+		t.Errorf("SimplifyStackTrace() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSimplifyStackTrace_NoFramesReturnsInputUnchanged(t *testing.T) {
+	raw := "Game paused on error (no stack trace available)"
+	if got := SimplifyStackTrace(raw); got != raw {
+		t.Errorf("SimplifyStackTrace() = %q, want input unchanged", got)
+	}
+}
+
+func TestSimplifyErrors_MergesSharedPrefixIntoTree(t *testing.T) {
+	traceA := `0. Function: update - Source: res://scripts/enemy.gd:20
+1. Function: _process - Source: res://scripts/enemy.gd:8`
+	traceB := `0. Function: update - Source: res://scripts/enemy.gd:20
+1. Function: _process - Source: res://scripts/enemy.gd:8`
+	traceC := `0. Function: take_damage - Source: res://scripts/enemy.gd:30
+1. Function: _process - Source: res://scripts/enemy.gd:8`
+
+	raw := traceA + "\n\n" + traceB + "\n\n" + traceC
+
+	want := `Function: _process - Source: res://scripts/enemy.gd:8 (x3)
+  Function: update - Source: res://scripts/enemy.gd:20 (x2)
+  Function: take_damage - Source: res://scripts/enemy.gd:30`
+
+	if got := SimplifyErrors(raw); got != want {
+		t.Errorf("SimplifyErrors() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSimplifyErrors_CollapsesRecursionWithinATrace(t *testing.T) {
+	raw := `0. Function: factorial - Source: res://scripts/math.gd:10
+1. Function: factorial - Source: res://scripts/math.gd:10
+2. Function: factorial - Source: res://scripts/math.gd:10
+3. Function: _ready - Source: res://scripts/main.gd:5`
+
+	want := `Function: _ready - Source: res://scripts/main.gd:5
+  Function: factorial - Source: res://scripts/math.gd:10 (x3)`
+
+	if got := SimplifyErrors(raw); got != want {
+		t.Errorf("SimplifyErrors() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSimplifyErrors_NoTracesReturnsInputUnchanged(t *testing.T) {
+	raw := "No errors"
+	if got := SimplifyErrors(raw); got != raw {
+		t.Errorf("SimplifyErrors() = %q, want input unchanged", got)
+	}
+}
+
+func TestParseFrames_SkipsNonFrameLines(t *testing.T) {
+	raw := `Game paused on error
+0. Function: _ready - Source: res://scripts/main.gd:5`
+
+	frames := ParseFrames(raw)
+	if len(frames) != 1 {
+		t.Fatalf("len(frames) = %d, want 1", len(frames))
+	}
+	want := Frame{Function: "_ready", Source: "res://scripts/main.gd", Line: 5}
+	if frames[0] != want {
+		t.Errorf("frames[0] = %+v, want %+v", frames[0], want)
+	}
+}
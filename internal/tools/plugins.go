@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/plugin"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterPluginTools adds one MCP tool per manifest mgr's plugins declared,
+// each proxied through mgr.Call to the plugin subprocess that owns it.
+func RegisterPluginTools(s *server.MCPServer, mgr *plugin.Manager) {
+	for _, t := range mgr.Tools() {
+		tool := mcp.NewToolWithRawSchema(t.Name, t.Description, t.Schema)
+		s.AddTool(tool, makePluginCall(mgr, t.Name))
+	}
+}
+
+func makePluginCall(mgr *plugin.Manager, name string) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		argsJSON, err := json.Marshal(req.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal args: %v", err)), nil
+		}
+
+		result, err := mgr.Call(ctx, name, argsJSON)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("plugin call failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	}
+}
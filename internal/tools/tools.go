@@ -2,15 +2,22 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/bridge"
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
 )
 
-// Register adds all Godot tools to the MCP server
-func Register(s *server.MCPServer, client *godot.Client) {
+// Register adds all Godot tools to the MCP server. registry resolves the
+// "project" argument each tool now accepts (see resolveProjectClient); tools
+// that omit it operate on registry's default project.
+func Register(s *server.MCPServer, registry *godot.ProjectRegistry) {
 	// run_main_scene - F5 equivalent
 	s.AddTool(
 		mcp.NewTool("run_main_scene",
@@ -21,8 +28,11 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithObject("overrides",
 				mcp.Description("Override autoload variables on startup. Map of autoload names to property overrides, e.g. {\"DebugManager\": {\"debug_mode\": true}}"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeRunMainScene(client),
+		makeRunMainScene(registry),
 	)
 
 	// run_scene - run specific scene
@@ -39,8 +49,11 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithObject("overrides",
 				mcp.Description("Override autoload variables on startup. Map of autoload names to property overrides, e.g. {\"DebugManager\": {\"debug_mode\": true}}"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeRunScene(client),
+		makeRunScene(registry),
 	)
 
 	// run_current_scene - run currently open scene
@@ -53,16 +66,22 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithObject("overrides",
 				mcp.Description("Override autoload variables on startup. Map of autoload names to property overrides, e.g. {\"DebugManager\": {\"debug_mode\": true}}"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeRunCurrentScene(client),
+		makeRunCurrentScene(registry),
 	)
 
 	// stop_scene - stop running game
 	s.AddTool(
 		mcp.NewTool("stop_scene",
 			mcp.WithDescription("Stop the currently running game/scene"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeStopScene(client),
+		makeStopScene(registry),
 	)
 
 	// get_output - get buffered output/logs
@@ -75,24 +94,39 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithBoolean("clear",
 				mcp.Description("If true, mark current position for future new_only calls"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetOutput(client),
+		makeGetOutput(registry),
 	)
 
 	// get_debugger_errors - get debugger errors/warnings
 	s.AddTool(
 		mcp.NewTool("get_debugger_errors",
 			mcp.WithDescription("Get errors and warnings from the Godot Debugger Errors tab"),
+			mcp.WithBoolean("simplified",
+				mcp.Description("Collapse repeated occurrences of the same error into one entry with a count, merging shared call-stack prefixes into a tree"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetDebugErrors(client),
+		makeGetDebugErrors(registry),
 	)
 
 	// get_debugger_stack_trace - get stack trace on runtime error
 	s.AddTool(
 		mcp.NewTool("get_debugger_stack_trace",
 			mcp.WithDescription("Get stack trace from Godot Debugger (populated when game crashes/pauses on error)"),
+			mcp.WithBoolean("simplified",
+				mcp.Description("Collapse consecutive identical frames (recursion) into one entry with a count"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetStackTrace(client),
+		makeGetStackTrace(registry),
 	)
 
 	// get_debugger_locals - get local variables for selected stack frame
@@ -102,16 +136,22 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithNumber("frame_index",
 				mcp.Description("Stack frame index (0=top/current, higher=callers). Defaults to currently selected frame."),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetLocals(client),
+		makeGetLocals(registry),
 	)
 
 	// get_remote_scene_tree - get instantiated node tree from running game
 	s.AddTool(
 		mcp.NewTool("get_remote_scene_tree",
 			mcp.WithDescription("Get instantiated node tree from running game (requires game to be running)"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetRemoteSceneTree(client),
+		makeGetRemoteSceneTree(registry),
 	)
 
 	// get_remote_node_properties - get properties of a specific node from running game
@@ -122,8 +162,11 @@ func Register(s *server.MCPServer, client *godot.Client) {
 				mcp.Required(),
 				mcp.Description("Path to node in remote scene tree, e.g. /root/game/Player"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetRemoteNodeProperties(client),
+		makeGetRemoteNodeProperties(registry),
 	)
 
 	// get_screenshot - capture game or editor viewport
@@ -134,16 +177,54 @@ func Register(s *server.MCPServer, client *godot.Client) {
 				mcp.Required(),
 				mcp.Description("What to capture: 'editor' (2D+3D editor viewports) or 'game' (requires screenshot_listener autoload in game project)"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeGetScreenshot(registry),
+	)
+
+	// start_screenshot_stream - begin continuous background frame capture
+	s.AddTool(
+		mcp.NewTool("start_screenshot_stream",
+			mcp.WithDescription("Start continuously capturing screenshots in the background so get_recent_frames can be polled over time. The stream runs until the MCP server shuts down; an editor disconnect or reconnect does not stop it."),
+			mcp.WithString("target",
+				mcp.Required(),
+				mcp.Description("What to capture: 'editor' or 'game' (requires screenshot_listener autoload in game project)"),
+			),
+			mcp.WithNumber("fps",
+				mcp.Description("Capture rate in frames per second (defaults to 1)"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetScreenshot(client),
+		makeStartScreenshotStream(registry),
+	)
+
+	// get_recent_frames - pull the last N frames captured by the stream
+	s.AddTool(
+		mcp.NewTool("get_recent_frames",
+			mcp.WithDescription("Get the last N frames captured by start_screenshot_stream as inline images"),
+			mcp.WithNumber("n",
+				mcp.Description("Number of most recent frames to return (defaults to 5)"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeGetRecentFrames(registry),
 	)
 
 	// get_monitors - get engine performance monitors
 	s.AddTool(
 		mcp.NewTool("get_monitors",
 			mcp.WithDescription("Get engine performance monitors (FPS, memory, object count, etc.) from the Debugger Monitors tab"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetMonitors(client),
+		makeGetMonitors(registry),
 	)
 
 	// set_breakpoint - set or remove a breakpoint
@@ -161,32 +242,53 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithBoolean("enabled",
 				mcp.Description("True to set breakpoint, false to remove (default: true)"),
 			),
+			mcp.WithString("condition",
+				mcp.Description("GDScript boolean expression; the breakpoint only stops execution when this evaluates true (evaluated server-side, no Godot-native support)"),
+			),
+			mcp.WithString("hit_condition",
+				mcp.Description("Hit-count expression, e.g. '>=5', '%10', '=3'; the breakpoint only stops once the hit count satisfies this"),
+			),
+			mcp.WithString("log_message",
+				mcp.Description("If set, turns this into a logpoint: instead of stopping, prints this message (with {expr} interpolations evaluated) to output and resumes"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeSetBreakpoint(client),
+		makeSetBreakpoint(registry),
 	)
 
 	// clear_breakpoints - remove all breakpoints
 	s.AddTool(
 		mcp.NewTool("clear_breakpoints",
 			mcp.WithDescription("Remove all breakpoints"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeClearBreakpoints(client),
+		makeClearBreakpoints(registry),
 	)
 
 	// get_debugger_state - check debugger state
 	s.AddTool(
 		mcp.NewTool("get_debugger_state",
 			mcp.WithDescription("Get current debugger state: whether paused at breakpoint, session active, debuggable"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeGetDebuggerState(client),
+		makeGetDebuggerState(registry),
 	)
 
 	// debug_continue - resume execution
 	s.AddTool(
 		mcp.NewTool("debug_continue",
 			mcp.WithDescription("Resume execution after hitting a breakpoint"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeDebugContinue(client),
+		makeDebugContinue(registry),
 	)
 
 	// debug_step - step through code
@@ -196,16 +298,22 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithString("mode",
 				mcp.Description("Step mode: 'into' (step into function), 'over' (step over/next line), 'out' (step out of function). Default: 'over'"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeDebugStep(client),
+		makeDebugStep(registry),
 	)
 
 	// debug_break - pause execution
 	s.AddTool(
 		mcp.NewTool("debug_break",
 			mcp.WithDescription("Pause execution of the running game"),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeDebugBreak(client),
+		makeDebugBreak(registry),
 	)
 
 	// evaluate_expression - evaluate GDScript in running game
@@ -216,8 +324,11 @@ func Register(s *server.MCPServer, client *godot.Client) {
 				mcp.Required(),
 				mcp.Description("GDScript expression to evaluate, e.g. 'get_node(\"/root/Main/Player\").health' or 'get_node(\"/root/Main\").set(\"speed\", 10)'"),
 			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
 		),
-		makeEvaluateExpression(client),
+		makeEvaluateExpression(registry),
 	)
 
 	// send_input - inject input events into running game
@@ -226,7 +337,7 @@ func Register(s *server.MCPServer, client *godot.Client) {
 			mcp.WithDescription("Send fake input events to the running game. Useful for automated testing. Requires game to be running with peek_runtime_helper autoload."),
 			mcp.WithString("type",
 				mcp.Required(),
-				mcp.Description("Input type: 'action', 'key', 'mouse_button', or 'mouse_motion'"),
+				mcp.Description("Input type: 'action', 'key', 'mouse_button', 'mouse_motion', 'screen_touch', 'screen_drag', 'magnify_gesture', 'pan_gesture', 'joypad_button', or 'joypad_motion'"),
 			),
 			mcp.WithString("action",
 				mcp.Description("Action name for type='action' (e.g., 'jump', 'fire', 'ui_accept')"),
@@ -238,20 +349,175 @@ func Register(s *server.MCPServer, client *godot.Client) {
 				mcp.Description("Mouse button for type='mouse_button': 'left', 'right', 'middle', 'wheel_up', 'wheel_down'"),
 			),
 			mcp.WithBoolean("pressed",
-				mcp.Description("Whether key/button is pressed (default: true)"),
+				mcp.Description("Whether key/button/touch is pressed (default: true)"),
 			),
 			mcp.WithNumber("strength",
 				mcp.Description("Analog strength 0.0-1.0 for actions (default: 1.0)"),
 			),
 			mcp.WithArray("position",
-				mcp.Description("Mouse position [x, y] for mouse events"),
+				mcp.Description("Position [x, y] for mouse/touch events"),
+			),
+			mcp.WithArray("global_position",
+				mcp.Description("Screen-space position [x, y] for mouse events, alongside viewport-relative 'position'"),
 			),
 			mcp.WithArray("relative",
-				mcp.Description("Relative motion [x, y] for mouse_motion"),
+				mcp.Description("Relative motion [x, y] for mouse_motion/screen_drag"),
+			),
+			mcp.WithArray("modifiers",
+				mcp.Description("Modifier keys held during a key/mouse event: any of 'shift', 'ctrl', 'alt', 'meta', 'command'"),
+			),
+			mcp.WithBoolean("echo",
+				mcp.Description("Whether type='key' is an OS auto-repeat echo of a held key (default: false)"),
+			),
+			mcp.WithNumber("index",
+				mcp.Description("Touch point index for type='screen_touch'/'screen_drag' (default: 0)"),
+			),
+			mcp.WithNumber("tap_count",
+				mcp.Description("Tap count for type='screen_touch' (default: 1)"),
+			),
+			mcp.WithNumber("factor",
+				mcp.Description("Zoom factor for type='magnify_gesture'"),
+			),
+			mcp.WithArray("delta",
+				mcp.Description("Pan delta [x, y] for type='pan_gesture'"),
+			),
+			mcp.WithNumber("device",
+				mcp.Description("Joypad device index for type='joypad_button'/'joypad_motion' (default: 0)"),
+			),
+			mcp.WithNumber("axis",
+				mcp.Description("Joypad axis index for type='joypad_motion' (e.g. 0 for left stick X)"),
+			),
+			mcp.WithNumber("axis_value",
+				mcp.Description("Joypad axis value -1.0 to 1.0 for type='joypad_motion'"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeSendInput(registry),
+	)
+
+	// send_input_sequence - dispatch a batch of timed input events in one call
+	s.AddTool(
+		mcp.NewTool("send_input_sequence",
+			mcp.WithDescription("Send an ordered sequence of input events to the running game in a single call, instead of one send_input call per event. Each event supports a delay_ms before it fires and an optional hold_ms auto-release. A 'text' event type expands a string into key press/release pairs (with shift held for uppercase/symbols) so you can type into a LineEdit/TextEdit in one call. Requires game to be running with peek_runtime_helper autoload."),
+			mcp.WithArray("events",
+				mcp.Required(),
+				mcp.Description("Ordered list of event objects. Each has 'type' ('action', 'key', 'mouse_button', 'mouse_motion', 'screen_touch', 'screen_drag', 'magnify_gesture', 'pan_gesture', 'joypad_button', 'joypad_motion', or 'text'), the fields that type needs (action/keycode/button/pressed/strength/position/global_position/relative/modifiers/echo/index/tap_count/factor/delta/device/axis/axis_value, or 'text' for type='text'), plus optional 'delay_ms' (wait before dispatch) and 'hold_ms' (auto-release this many ms after press)"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeSendInputSequence(registry),
+	)
+
+	// start_input_recording / stop_input_recording - capture a live demonstration
+	// and hand it back in the send_input_sequence schema for replay
+	s.AddTool(
+		mcp.NewTool("start_input_recording",
+			mcp.WithDescription("Start recording live input events from the running game (keyboard, mouse, touch, joypad) for later replay via send_input_sequence. Call stop_input_recording to end the capture and retrieve the recorded events. Requires game to be running with peek_runtime_helper autoload."),
+			mcp.WithArray("event_types",
+				mcp.Description("Restrict capture to these event types (e.g. ['key', 'mouse_button']); omit to capture everything send_input supports"),
+			),
+			mcp.WithString("node_path",
+				mcp.Description("Restrict capture to input observed by this node's _unhandled_input, instead of the whole scene"),
+			),
+			mcp.WithNumber("max_duration_seconds",
+				mcp.Description("Auto-stop the recording after this many seconds, so a forgotten recording doesn't grow unbounded"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeStartInputRecording(registry),
+	)
+
+	s.AddTool(
+		mcp.NewTool("stop_input_recording",
+			mcp.WithDescription("Stop an in-progress input recording and return the captured events as a send_input_sequence-compatible events array."),
+			mcp.WithString("save_path",
+				mcp.Description("Project-relative path (e.g. 'res://recordings/login.json') to persist the recording alongside returning it"),
+			),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
 			),
 		),
-		makeSendInput(client),
+		makeStopInputRecording(registry),
 	)
+
+	// godot_status - connection health, since the editor can restart mid-session
+	s.AddTool(
+		mcp.NewTool("godot_status",
+			mcp.WithDescription("Report whether the MCP server is currently connected to the Godot editor, the socket path it's watching, and the last connection error if any. Useful after the editor restarts mid-session, since the server reconnects automatically rather than requiring an MCP client restart."),
+			mcp.WithString("project",
+				mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+			),
+		),
+		makeGodotStatus(registry),
+	)
+
+	// godot_list_projects - enumerate every project this server fronts
+	s.AddTool(
+		mcp.NewTool("godot_list_projects",
+			mcp.WithDescription("List every Godot project this MCP server knows about (the default project plus any discovered or explicitly configured via --project), and whether each is currently connected. Pass the 'name' returned here as the 'project' argument to other tools to target that project."),
+		),
+		makeGodotListProjects(registry),
+	)
+
+	// godot_call_method - invoke a third-party method registered via
+	// godot.RegisterMethod, without this repo knowing about it ahead of time
+	if len(godot.RegisteredMethods()) > 0 {
+		s.AddTool(
+			mcp.NewTool("godot_call_method",
+				mcp.WithDescription("Call a third-party Godot method registered via a MethodPlugin. Available method names are listed in the 'method' parameter's description."),
+				mcp.WithString("method",
+					mcp.Required(),
+					mcp.Description(fmt.Sprintf("Registered method name. Available: %v", godot.RegisteredMethods())),
+				),
+				mcp.WithObject("params",
+					mcp.Description("Params object forwarded to the method, shaped per its ParamsPrototype()"),
+				),
+				mcp.WithString("project",
+					mcp.Description("Named project to target (see godot_list_projects); defaults to the server's default project"),
+				),
+			),
+			makeCallMethod(registry),
+		)
+	}
+}
+
+// reconnectWaitTimeout bounds how long a tool call waits for client.Supervise
+// to land a reconnect before giving up with an EditorOfflineError, rather
+// than failing instantly on a transient editor restart.
+const reconnectWaitTimeout = 3 * time.Second
+
+// waitForConnection waits up to reconnectWaitTimeout for client to be (or
+// become) connected. It returns nil if connected, or a structured "editor
+// offline" tool result with a retry hint otherwise.
+func waitForConnection(ctx context.Context, client *godot.Client) *mcp.CallToolResult {
+	if err := client.WaitUntilConnected(ctx, reconnectWaitTimeout); err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	return nil
+}
+
+// resolveProjectClient picks the *godot.Client a tool call should use: the
+// project named by the optional "project" argument, or registry's default
+// project when it's omitted. It returns a ready-to-return tool error for an
+// unknown project name.
+func resolveProjectClient(registry *godot.ProjectRegistry, req mcp.CallToolRequest) (*godot.Client, *mcp.CallToolResult) {
+	args := req.GetArguments()
+	name, _ := args["project"].(string)
+	if name == "" {
+		return registry.Default(), nil
+	}
+
+	client, ok := registry.Get(name)
+	if !ok {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("unknown project %q (see godot_list_projects)", name))
+	}
+	return client, nil
 }
 
 // getTimeoutArg extracts the optional timeout_seconds arg from request
@@ -289,10 +555,15 @@ func getOverridesArg(req mcp.CallToolRequest) godot.Overrides {
 	return result
 }
 
-func makeRunMainScene(client *godot.Client) server.ToolHandlerFunc {
+func makeRunMainScene(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		timeout := getTimeoutArg(req)
@@ -318,10 +589,15 @@ func makeRunMainScene(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeRunScene(client *godot.Client) server.ToolHandlerFunc {
+func makeRunScene(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		scenePath, err := req.RequireString("scene_path")
@@ -352,10 +628,15 @@ func makeRunScene(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeRunCurrentScene(client *godot.Client) server.ToolHandlerFunc {
+func makeRunCurrentScene(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		timeout := getTimeoutArg(req)
@@ -381,10 +662,15 @@ func makeRunCurrentScene(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeStopScene(client *godot.Client) server.ToolHandlerFunc {
+func makeStopScene(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		if err := client.StopScene(ctx); err != nil {
@@ -395,10 +681,15 @@ func makeStopScene(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetOutput(client *godot.Client) server.ToolHandlerFunc {
+func makeGetOutput(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		clear := false
@@ -426,13 +717,25 @@ func makeGetOutput(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetDebugErrors(client *godot.Client) server.ToolHandlerFunc {
+func makeGetDebugErrors(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
+		}
+
+		simplified := false
+		if args := req.GetArguments(); args != nil {
+			if v, ok := args["simplified"].(bool); ok {
+				simplified = v
+			}
 		}
 
-		result, err := client.GetDebugErrors(ctx)
+		result, err := client.GetDebugErrors(ctx, godot.GetDebugErrorsParams{Simplified: simplified})
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to get debug errors: %v", err)), nil
 		}
@@ -445,13 +748,25 @@ func makeGetDebugErrors(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetStackTrace(client *godot.Client) server.ToolHandlerFunc {
+func makeGetStackTrace(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
+		}
+
+		simplified := false
+		if args := req.GetArguments(); args != nil {
+			if v, ok := args["simplified"].(bool); ok {
+				simplified = v
+			}
 		}
 
-		result, err := client.GetStackTrace(ctx)
+		result, err := client.GetStackTrace(ctx, godot.GetStackTraceParams{Simplified: simplified})
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to get stack trace: %v", err)), nil
 		}
@@ -464,10 +779,15 @@ func makeGetStackTrace(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetLocals(client *godot.Client) server.ToolHandlerFunc {
+func makeGetLocals(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		frameIndex := -1 // default: use currently selected frame
@@ -487,20 +807,19 @@ func makeGetLocals(client *godot.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultText("No locals (game not paused on error, or no frame selected)"), nil
 		}
 
-		// format as readable text
-		var output string
-		for _, local := range result.Locals {
-			output += fmt.Sprintf("%s = %s\n", local.Name, local.Value)
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(bridge.FormatNameValueLines(result.Locals)), nil
 	}
 }
 
-func makeGetRemoteSceneTree(client *godot.Client) server.ToolHandlerFunc {
+func makeGetRemoteSceneTree(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		result, err := client.GetRemoteSceneTree(ctx)
@@ -516,10 +835,15 @@ func makeGetRemoteSceneTree(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetRemoteNodeProperties(client *godot.Client) server.ToolHandlerFunc {
+func makeGetRemoteNodeProperties(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		nodePath, err := req.RequireString("node_path")
@@ -536,20 +860,19 @@ func makeGetRemoteNodeProperties(client *godot.Client) server.ToolHandlerFunc {
 			return mcp.NewToolResultText("No properties (node not found or game not running)"), nil
 		}
 
-		// format as readable text
-		var output string
-		for _, prop := range result.Properties {
-			output += fmt.Sprintf("%s = %s\n", prop.Name, prop.Value)
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(bridge.FormatNameValueLines(result.Properties)), nil
 	}
 }
 
-func makeGetScreenshot(client *godot.Client) server.ToolHandlerFunc {
+func makeGetScreenshot(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		target, err := req.RequireString("target")
@@ -570,10 +893,80 @@ func makeGetScreenshot(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetMonitors(client *godot.Client) server.ToolHandlerFunc {
+func makeStartScreenshotStream(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
+		}
+
+		target, err := req.RequireString("target")
+		if err != nil {
+			return mcp.NewToolResultError("missing required parameter: target"), nil
+		}
+		if target != "editor" && target != "game" {
+			return mcp.NewToolResultError("target must be 'editor' or 'game'"), nil
+		}
+
+		fps := 1
+		if args := req.GetArguments(); args != nil {
+			if v, ok := args["fps"].(float64); ok && v > 0 {
+				fps = int(v)
+			}
+		}
+
+		// tied to the client's own lifetime, not this request's short-lived
+		// ctx, so the stream keeps running after the tool call returns
+		if _, err := client.StreamScreenshots(client.Context(), target, fps); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to start screenshot stream: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Screenshot stream started (target=%s, fps=%d)", target, fps)), nil
+	}
+}
+
+func makeGetRecentFrames(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		n := 5
+		if args := req.GetArguments(); args != nil {
+			if v, ok := args["n"].(float64); ok && v > 0 {
+				n = int(v)
+			}
+		}
+
+		frames := client.RecentFrames(n)
+		if len(frames) == 0 {
+			return mcp.NewToolResultText("No frames captured yet"), nil
+		}
+
+		content := make([]mcp.Content, 0, len(frames)*2)
+		for i, f := range frames {
+			content = append(content, mcp.NewTextContent(fmt.Sprintf("Frame %d: %s %.0fx%.0f at %s", i, f.Target, f.Width, f.Height, f.Timestamp.Format(time.RFC3339))))
+			content = append(content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(f.PNG), "image/png"))
+		}
+
+		return &mcp.CallToolResult{Content: content}, nil
+	}
+}
+
+func makeGetMonitors(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		result, err := client.GetMonitors(ctx)
@@ -598,10 +991,15 @@ func makeGetMonitors(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeSetBreakpoint(client *godot.Client) server.ToolHandlerFunc {
+func makeSetBreakpoint(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		path, err := req.RequireString("path")
@@ -616,14 +1014,24 @@ func makeSetBreakpoint(client *godot.Client) server.ToolHandlerFunc {
 		line := int(lineFloat)
 
 		enabled := true
+		condition, hitCondition, logMessage := "", "", ""
 		args := req.GetArguments()
 		if args != nil {
 			if v, ok := args["enabled"].(bool); ok {
 				enabled = v
 			}
+			if v, ok := args["condition"].(string); ok {
+				condition = v
+			}
+			if v, ok := args["hit_condition"].(string); ok {
+				hitCondition = v
+			}
+			if v, ok := args["log_message"].(string); ok {
+				logMessage = v
+			}
 		}
 
-		_, err = client.SetBreakpoint(ctx, path, line, enabled)
+		_, err = client.SetBreakpoint(ctx, path, line, enabled, condition, hitCondition, logMessage)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to set breakpoint: %v", err)), nil
 		}
@@ -635,10 +1043,15 @@ func makeSetBreakpoint(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeClearBreakpoints(client *godot.Client) server.ToolHandlerFunc {
+func makeClearBreakpoints(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		_, err := client.ClearBreakpoints(ctx)
@@ -650,10 +1063,15 @@ func makeClearBreakpoints(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeGetDebuggerState(client *godot.Client) server.ToolHandlerFunc {
+func makeGetDebuggerState(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		result, err := client.GetDebuggerState(ctx)
@@ -670,14 +1088,36 @@ func makeGetDebuggerState(client *godot.Client) server.ToolHandlerFunc {
 		output += fmt.Sprintf("Active: %v\n", result.Active)
 		output += fmt.Sprintf("Debuggable: %v", result.Debuggable)
 
+		if len(result.ConditionalBreakpoints) > 0 {
+			output += "\n\nConditional/logpoint breakpoints:\n"
+			for _, bp := range result.ConditionalBreakpoints {
+				output += fmt.Sprintf("  %s:%d hits=%d", bp.Path, bp.Line, bp.HitCount)
+				if bp.Condition != "" {
+					output += fmt.Sprintf(" condition=%q", bp.Condition)
+				}
+				if bp.HitCondition != "" {
+					output += fmt.Sprintf(" hit_condition=%q", bp.HitCondition)
+				}
+				if bp.LogMessage != "" {
+					output += fmt.Sprintf(" log_message=%q", bp.LogMessage)
+				}
+				output += "\n"
+			}
+		}
+
 		return mcp.NewToolResultText(output), nil
 	}
 }
 
-func makeDebugContinue(client *godot.Client) server.ToolHandlerFunc {
+func makeDebugContinue(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		_, err := client.DebugContinue(ctx)
@@ -689,42 +1129,47 @@ func makeDebugContinue(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeDebugStep(client *godot.Client) server.ToolHandlerFunc {
+func makeDebugStep(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
-		mode := "over"
+		rawMode := ""
 		args := req.GetArguments()
 		if args != nil {
-			if v, ok := args["mode"].(string); ok && v != "" {
-				mode = v
+			if v, ok := args["mode"].(string); ok {
+				rawMode = v
 			}
 		}
 
-		if mode != "into" && mode != "over" && mode != "out" {
-			return mcp.NewToolResultError("mode must be 'into', 'over', or 'out'"), nil
+		mode, err := bridge.NormalizeStepMode(rawMode)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		_, err := client.DebugStep(ctx, mode)
-		if err != nil {
+		if _, err := client.DebugStep(ctx, mode); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to step: %v", err)), nil
 		}
 
-		modeDesc := map[string]string{
-			"into": "Stepped into function",
-			"over": "Stepped to next line",
-			"out":  "Stepped out of function",
-		}
-		return mcp.NewToolResultText(modeDesc[mode]), nil
+		return mcp.NewToolResultText(bridge.StepModeDescription(mode)), nil
 	}
 }
 
-func makeDebugBreak(client *godot.Client) server.ToolHandlerFunc {
+func makeDebugBreak(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !client.IsConnected() {
-			return mcp.NewToolResultError("not connected to Godot editor"), nil
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
 		}
 
 		_, err := client.DebugBreak(ctx)
@@ -736,9 +1181,14 @@ func makeDebugBreak(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeEvaluateExpression(client *godot.Client) server.ToolHandlerFunc {
+func makeEvaluateExpression(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// note: doesn't require C++ connection, talks directly to game via UDP
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
 		expression, err := req.RequireString("expression")
 		if err != nil {
 			return mcp.NewToolResultError("missing required parameter: expression"), nil
@@ -753,18 +1203,112 @@ func makeEvaluateExpression(client *godot.Client) server.ToolHandlerFunc {
 	}
 }
 
-func makeSendInput(client *godot.Client) server.ToolHandlerFunc {
+func makeCallMethod(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if result := waitForConnection(ctx, client); result != nil {
+			return result, nil
+		}
+
+		method, err := req.RequireString("method")
+		if err != nil {
+			return mcp.NewToolResultError("missing required parameter: method"), nil
+		}
+
+		var params interface{}
+		args := req.GetArguments()
+		if args != nil {
+			params = args["params"]
+		}
+
+		result, err := client.CallMethod(ctx, method, params)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to call %s: %v", method, err)), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// modifierParams converts a "modifiers" array (values like "shift", "ctrl",
+// "alt", "meta", "command") into the shift_pressed/ctrl_pressed/alt_pressed/
+// meta_pressed fields InputEventWithModifiers expects. "command" maps to
+// meta_pressed, matching Godot's cross-platform Cmd/Meta convention.
+func modifierParams(raw interface{}) map[string]interface{} {
+	mods, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	params := make(map[string]interface{})
+	for _, m := range mods {
+		name, ok := m.(string)
+		if !ok {
+			continue
+		}
+		switch name {
+		case "shift":
+			params["shift_pressed"] = true
+		case "ctrl":
+			params["ctrl_pressed"] = true
+		case "alt":
+			params["alt_pressed"] = true
+		case "meta", "command":
+			params["meta_pressed"] = true
+		}
+	}
+	return params
+}
+
+func makeGodotStatus(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		data, err := json.Marshal(client.Status())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func makeGodotListProjects(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(registry.List())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func makeSendInput(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// note: doesn't require C++ connection, talks directly to game via UDP
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
 		inputType, err := req.RequireString("type")
 		if err != nil {
 			return mcp.NewToolResultError("missing required parameter: type"), nil
 		}
 
 		// validate input type
-		validTypes := map[string]bool{"action": true, "key": true, "mouse_button": true, "mouse_motion": true}
-		if !validTypes[inputType] {
-			return mcp.NewToolResultError("type must be 'action', 'key', 'mouse_button', or 'mouse_motion'"), nil
+		if !validInputTypes[inputType] {
+			return mcp.NewToolResultError("type must be 'action', 'key', 'mouse_button', 'mouse_motion', 'screen_touch', 'screen_drag', 'magnify_gesture', 'pan_gesture', 'joypad_button', or 'joypad_motion'"), nil
 		}
 
 		// build params map from request arguments
@@ -791,9 +1335,39 @@ func makeSendInput(client *godot.Client) server.ToolHandlerFunc {
 			if v, ok := args["position"].([]interface{}); ok {
 				params["position"] = v
 			}
+			if v, ok := args["global_position"].([]interface{}); ok {
+				params["global_position"] = v
+			}
 			if v, ok := args["relative"].([]interface{}); ok {
 				params["relative"] = v
 			}
+			if v, ok := args["echo"].(bool); ok {
+				params["echo"] = v
+			}
+			for k, v := range modifierParams(args["modifiers"]) {
+				params[k] = v
+			}
+			if v, ok := args["index"].(float64); ok {
+				params["index"] = v
+			}
+			if v, ok := args["tap_count"].(float64); ok {
+				params["tap_count"] = v
+			}
+			if v, ok := args["factor"].(float64); ok {
+				params["factor"] = v
+			}
+			if v, ok := args["delta"].([]interface{}); ok {
+				params["delta"] = v
+			}
+			if v, ok := args["device"].(float64); ok {
+				params["device"] = v
+			}
+			if v, ok := args["axis"].(float64); ok {
+				params["axis"] = v
+			}
+			if v, ok := args["axis_value"].(float64); ok {
+				params["axis_value"] = v
+			}
 		}
 
 		result, err := client.SendInput(ctx, inputType, params)
@@ -804,3 +1378,229 @@ func makeSendInput(client *godot.Client) server.ToolHandlerFunc {
 		return mcp.NewToolResultText(fmt.Sprintf("Input sent: %s", result.Type)), nil
 	}
 }
+
+// validInputTypes are the event "type" values send_input and
+// send_input_sequence forward to the game autoload's InputEvent
+// constructors.
+var validInputTypes = map[string]bool{
+	"action": true, "key": true, "mouse_button": true, "mouse_motion": true,
+	"screen_touch": true, "screen_drag": true, "magnify_gesture": true, "pan_gesture": true,
+	"joypad_button": true, "joypad_motion": true,
+}
+
+// sendInputSequenceValidTypes extends validInputTypes with "text", which
+// send_input_sequence handles client-side (expanded into key steps) rather
+// than forwarding to the game autoload.
+var sendInputSequenceValidTypes = func() map[string]bool {
+	types := map[string]bool{"text": true}
+	for t := range validInputTypes {
+		types[t] = true
+	}
+	return types
+}()
+
+// parseInputSequenceStep converts one events[] entry into a godot.InputSequenceStep,
+// or multiple steps if it's a type="text" entry.
+func parseInputSequenceStep(raw map[string]interface{}) ([]godot.InputSequenceStep, error) {
+	eventType, _ := raw["type"].(string)
+	if !sendInputSequenceValidTypes[eventType] {
+		return nil, fmt.Errorf("type must be one of: action, key, mouse_button, mouse_motion, screen_touch, screen_drag, magnify_gesture, pan_gesture, joypad_button, joypad_motion, text")
+	}
+
+	delayMs := 0
+	if v, ok := raw["delay_ms"].(float64); ok {
+		delayMs = int(v)
+	}
+	holdMs := 0
+	if v, ok := raw["hold_ms"].(float64); ok {
+		holdMs = int(v)
+	}
+
+	if eventType == "text" {
+		text, _ := raw["text"].(string)
+		steps := godot.ExpandTextInput(text)
+		if len(steps) > 0 {
+			steps[0].DelayMs = delayMs
+		}
+		return steps, nil
+	}
+
+	step := godot.InputSequenceStep{
+		Type:    eventType,
+		Pressed: true,
+		DelayMs: delayMs,
+		HoldMs:  holdMs,
+	}
+	if v, ok := raw["action"].(string); ok {
+		step.Action = v
+	}
+	if v, ok := raw["keycode"].(string); ok {
+		step.Keycode = v
+	}
+	if v, ok := raw["button"].(string); ok {
+		step.Button = v
+	}
+	if v, ok := raw["pressed"].(bool); ok {
+		step.Pressed = v
+	}
+	if v, ok := raw["strength"].(float64); ok {
+		step.Strength = v
+	}
+	if v, ok := raw["position"].([]interface{}); ok {
+		step.Position = v
+	}
+	if v, ok := raw["global_position"].([]interface{}); ok {
+		step.GlobalPosition = v
+	}
+	if v, ok := raw["relative"].([]interface{}); ok {
+		step.Relative = v
+	}
+	if v, ok := raw["echo"].(bool); ok {
+		step.Echo = v
+	}
+	if mods, ok := raw["modifiers"].([]interface{}); ok {
+		for name, v := range modifierParams(mods) {
+			switch name {
+			case "shift_pressed":
+				step.ShiftPressed = v.(bool)
+			case "ctrl_pressed":
+				step.CtrlPressed = v.(bool)
+			case "alt_pressed":
+				step.AltPressed = v.(bool)
+			case "meta_pressed":
+				step.MetaPressed = v.(bool)
+			}
+		}
+	}
+	if v, ok := raw["index"].(float64); ok {
+		step.Index = v
+	}
+	if v, ok := raw["tap_count"].(float64); ok {
+		step.TapCount = v
+	}
+	if v, ok := raw["factor"].(float64); ok {
+		step.Factor = v
+	}
+	if v, ok := raw["delta"].([]interface{}); ok {
+		step.Delta = v
+	}
+	if v, ok := raw["device"].(float64); ok {
+		step.Device = v
+	}
+	if v, ok := raw["axis"].(float64); ok {
+		step.Axis = v
+	}
+	if v, ok := raw["axis_value"].(float64); ok {
+		step.AxisValue = v
+	}
+	return []godot.InputSequenceStep{step}, nil
+}
+
+func makeSendInputSequence(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// note: doesn't require C++ connection, talks directly to game via UDP
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		args := req.GetArguments()
+		rawEvents, ok := args["events"].([]interface{})
+		if !ok || len(rawEvents) == 0 {
+			return mcp.NewToolResultError("missing required parameter: events (non-empty array)"), nil
+		}
+
+		var steps []godot.InputSequenceStep
+		for i, rawEvent := range rawEvents {
+			eventMap, ok := rawEvent.(map[string]interface{})
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("events[%d] must be an object", i)), nil
+			}
+			expanded, err := parseInputSequenceStep(eventMap)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("events[%d]: %v", i, err)), nil
+			}
+			steps = append(steps, expanded...)
+		}
+
+		result, err := client.SendInputSequence(ctx, steps)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to send input sequence: %v", err)), nil
+		}
+
+		var lines []string
+		lines = append(lines, fmt.Sprintf("Delivered %d/%d steps", result.Delivered, result.Total))
+		for _, step := range result.Steps {
+			if !step.Delivered {
+				lines = append(lines, fmt.Sprintf("  [%d] %s: FAILED (%s)", step.Index, step.Type, step.Error))
+			}
+		}
+
+		return mcp.NewToolResultText(strings.Join(lines, "\n")), nil
+	}
+}
+
+func makeStartInputRecording(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// note: doesn't require C++ connection, talks directly to game via UDP
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		params := godot.StartInputRecordingParams{}
+		args := req.GetArguments()
+		if args != nil {
+			if v, ok := args["event_types"].([]interface{}); ok {
+				for _, t := range v {
+					if s, ok := t.(string); ok {
+						params.EventTypes = append(params.EventTypes, s)
+					}
+				}
+			}
+			if v, ok := args["node_path"].(string); ok {
+				params.NodePath = v
+			}
+			if v, ok := args["max_duration_seconds"].(float64); ok {
+				params.MaxDurationSeconds = v
+			}
+		}
+
+		result, err := client.StartInputRecording(ctx, params)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to start input recording: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Recording started: %v", result.Recording)), nil
+	}
+}
+
+func makeStopInputRecording(registry *godot.ProjectRegistry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// note: doesn't require C++ connection, talks directly to game via UDP
+		client, errResult := resolveProjectClient(registry, req)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		savePath := ""
+		args := req.GetArguments()
+		if args != nil {
+			if v, ok := args["save_path"].(string); ok {
+				savePath = v
+			}
+		}
+
+		result, err := client.StopInputRecording(ctx, savePath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to stop input recording: %v", err)), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
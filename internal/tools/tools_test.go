@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/PrajnaAvidya/godot-peek-mcp/internal/godot"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newToolRequest builds a CallToolRequest carrying args, the way an MCP
+// client's actual JSON-RPC call would arrive.
+func newToolRequest(args map[string]interface{}) mcp.CallToolRequest {
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	return req
+}
+
+// replayRegistry wires a single default project backed by a replay client
+// fed from fixture, so a handler can be exercised without a live Godot
+// editor. Mirrors NewReplayClient's doc comment: "deterministic bug
+// reproducers, tools.Register fixtures".
+func replayRegistry(t *testing.T, fixture []godot.RecordedEvent) *godot.ProjectRegistry {
+	t.Helper()
+	client := godot.NewReplayClient("test", fixture)
+	return godot.NewProjectRegistry("default", client)
+}
+
+func TestRegister_WiresUpToolsWithoutPanic(t *testing.T) {
+	registry := replayRegistry(t, nil)
+	s := server.NewMCPServer("test", "0.0.0")
+	Register(s, registry)
+}
+
+func TestGetOutput_ReplaysRecordedSession(t *testing.T) {
+	fixture := []godot.RecordedEvent{
+		{Direction: "request", ID: 1, Method: "get_output", Params: mustJSON(t, godot.GetOutputParams{})},
+		{Direction: "response", ID: 1, Method: "get_output", Result: rawJSON(t, godot.OutputResult{
+			Output: "hello from replay", Length: 18, TotalLength: 18,
+		})},
+	}
+	registry := replayRegistry(t, fixture)
+
+	handler := makeGetOutput(registry)
+	result, err := handler(context.Background(), newToolRequest(nil))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	text := resultText(t, result)
+	if !strings.Contains(text, "hello from replay") {
+		t.Errorf("expected replayed output in result, got %q", text)
+	}
+}
+
+func TestGetDebuggerState_ReplaysRecordedSession(t *testing.T) {
+	fixture := []godot.RecordedEvent{
+		{Direction: "request", ID: 1, Method: "get_debugger_state"},
+		{Direction: "response", ID: 1, Method: "get_debugger_state", Result: rawJSON(t, godot.DebuggerStateResult{
+			Paused: true, Active: true, Debuggable: true,
+		})},
+	}
+	registry := replayRegistry(t, fixture)
+
+	handler := makeGetDebuggerState(registry)
+	result, err := handler(context.Background(), newToolRequest(nil))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	text := resultText(t, result)
+	if !strings.Contains(text, "PAUSED") {
+		t.Errorf("expected paused state in result, got %q", text)
+	}
+}
+
+func TestResolveProjectClient_UnknownProjectErrors(t *testing.T) {
+	registry := replayRegistry(t, nil)
+	req := newToolRequest(map[string]interface{}{"project": "does-not-exist"})
+
+	client, errResult := resolveProjectClient(registry, req)
+	if client != nil {
+		t.Errorf("expected nil client for unknown project, got %v", client)
+	}
+	if errResult == nil {
+		t.Fatal("expected an error result for unknown project")
+	}
+}
+
+// mustJSON marshals v for embedding in a RecordedEvent fixture.
+func mustJSON(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal fixture value: %v", err)
+	}
+	return data
+}
+
+// rawJSON marshals v into a *json.RawMessage, matching RecordedEvent.Result's type.
+func rawJSON(t *testing.T, v interface{}) *json.RawMessage {
+	t.Helper()
+	raw := mustJSON(t, v)
+	return &raw
+}
+
+// resultText extracts the text of a CallToolResult produced by
+// mcp.NewToolResultText, failing the test if result has no text content.
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	for _, c := range result.Content {
+		if tc, ok := c.(mcp.TextContent); ok {
+			return tc.Text
+		}
+	}
+	t.Fatalf("result has no text content: %+v", result)
+	return ""
+}